@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+)
+
+// checkpoint records the progress of a backfill run. It keeps the run as it
+// was asked for, so it still matches once resumed: From is the first date of
+// the run, To the -to flag, empty when the run goes up to yesterday, and Sink
+// where the games are written, as dates done in one sink are missing in
+// another.
+type checkpoint struct {
+	LeagueID nba.LeagueID `json:"league_id"`
+	Season   string       `json:"season,omitempty"`
+	From     string       `json:"from"`
+	To       string       `json:"to,omitempty"`
+	Sink     string       `json:"sink"`
+	// LastDate is the last date written with every date before it. Dates with
+	// games not over yet hold it back, the ones written after them are in Done.
+	LastDate string   `json:"last_date"`
+	Done     []string `json:"done,omitempty"`
+	// Offsets are the sizes of the files of a rewinder sink once LastDate and
+	// Done were written. Anything past them is a date left half written.
+	Offsets map[string]int64 `json:"offsets,omitempty"`
+}
+
+func loadCheckpoint(path string) (checkpoint, error) {
+	var cp checkpoint
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cp, nil
+	}
+
+	if err != nil {
+		return cp, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return cp, fmt.Errorf("failed to decode checkpoint: %w", err)
+	}
+
+	return cp, nil
+}
+
+// newCheckpoint starts a run from the first date
+func newCheckpoint(league nba.LeagueID, opts options, from time.Time) checkpoint {
+	return checkpoint{
+		LeagueID: league,
+		Season:   opts.season,
+		From:     from.Format(dateFormat),
+		To:       opts.to,
+		Sink:     sinkName(opts),
+		LastDate: from.AddDate(0, 0, -1).Format(dateFormat),
+	}
+}
+
+// resumes reports whether the checkpoint belongs to the same run
+func (cp checkpoint) resumes(other checkpoint) bool {
+	return cp.LastDate != "" &&
+		cp.LeagueID == other.LeagueID &&
+		cp.Season == other.Season &&
+		cp.From == other.From &&
+		cp.To == other.To &&
+		cp.Sink == other.Sink
+}
+
+// sinkName identifies the sink of the options, like db:/data/stats.db or
+// out:/data/backfill
+func sinkName(opts options) string {
+	kind, path := "out", opts.out
+	if opts.db != "" {
+		kind, path = "db", opts.db
+	}
+
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	return kind + ":" + path
+}
+
+// next returns the first date that still has to be backfilled
+func (cp checkpoint) next() time.Time {
+	last, _ := time.Parse(dateFormat, cp.LastDate)
+
+	return last.AddDate(0, 0, 1)
+}
+
+// done reports whether the date was written already
+func (cp checkpoint) done(d time.Time) bool {
+	return d.Before(cp.next()) || slices.Contains(cp.Done, d.Format(dateFormat))
+}
+
+// complete records the date as written, moving LastDate over the dates
+// written after it when it was the one holding it back
+func (cp *checkpoint) complete(d time.Time) {
+	cp.Done = append(cp.Done, d.Format(dateFormat))
+
+	for {
+		next := cp.next().Format(dateFormat)
+
+		i := slices.Index(cp.Done, next)
+		if i < 0 {
+			break
+		}
+
+		cp.LastDate = next
+		cp.Done = slices.Delete(cp.Done, i, i+1)
+	}
+
+	if len(cp.Done) == 0 {
+		cp.Done = nil
+	}
+}
+
+// save writes the checkpoint atomically so a crash never leaves it half written
+func (cp checkpoint) save(path string) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	var (
+		path = filepath.Join(t.TempDir(), "checkpoint.json")
+		opts = options{from: "2022-10-18", out: "backfill"}
+		day  = time.Date(2022, 10, 18, 0, 0, 0, 0, time.UTC)
+	)
+
+	cp, err := loadCheckpoint(path)
+	require.NoError(t, err)
+	assert.False(t, cp.resumes(newCheckpoint(nba.NBA, opts, day)), "there is no checkpoint yet")
+
+	cp = newCheckpoint(nba.NBA, opts, day)
+	assert.Equal(t, day, cp.next())
+
+	// The second day has games not over yet, it holds the run back.
+	cp.complete(day)
+	cp.complete(day.AddDate(0, 0, 2))
+	require.NoError(t, cp.save(path))
+
+	saved, err := loadCheckpoint(path)
+	require.NoError(t, err)
+
+	// The run resumes once -to, defaulting to yesterday, moved on.
+	assert.True(t, saved.resumes(newCheckpoint(nba.NBA, opts, day)))
+	assert.Equal(t, "2022-10-18", saved.From)
+	assert.True(t, saved.resumes(newCheckpoint(nba.NBA, options{from: "2022-10-18", out: "./backfill/"}, day)), "the sink is the same directory")
+	assert.Equal(t, day.AddDate(0, 0, 1), saved.next())
+	assert.True(t, saved.done(day))
+	assert.False(t, saved.done(day.AddDate(0, 0, 1)))
+	assert.True(t, saved.done(day.AddDate(0, 0, 2)), "dates written after the unfinished one aren't written twice")
+
+	saved.complete(day.AddDate(0, 0, 1))
+	assert.Equal(t, "2022-10-20", saved.LastDate)
+	assert.Empty(t, saved.Done)
+
+	for _, other := range []checkpoint{
+		newCheckpoint(nba.WNBA, opts, day),
+		newCheckpoint(nba.NBA, options{from: "2022-10-18", to: "2022-10-30", out: "backfill"}, day),
+		newCheckpoint(nba.NBA, options{from: "2022-10-18", db: "backfill"}, day),
+		newCheckpoint(nba.NBA, options{from: "2022-10-18", out: "other"}, day),
+		newCheckpoint(nba.NBA, opts, day.AddDate(0, 0, 1)),
+	} {
+		assert.False(t, saved.resumes(other), "another run starts over")
+	}
+}
@@ -0,0 +1,392 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/storage"
+	"go.uber.org/zap"
+)
+
+const (
+	dateFormat = "2006-01-02"
+
+	// unplayedAfter is how long after their start games that aren't final are
+	// taken as never played, like postponed or cancelled ones. Their date is
+	// complete without them instead of retried forever.
+	unplayedAfter = 48 * time.Hour
+)
+
+type (
+	config struct {
+		NBA struct {
+			CDNBaseURL  string        `split_words:"true" required:"true"`
+			BaseURL     string        `split_words:"true" required:"true"`
+			Timeout     time.Duration `default:"120s"`
+			LeaguesFile string        `split_words:"true"`
+		}
+
+		WNBA struct {
+			CDNBaseURL string `split_words:"true" required:"true"`
+		}
+	}
+
+	options struct {
+		league     string
+		from       string
+		to         string
+		season     string
+		rate       time.Duration
+		checkpoint string
+		db         string
+		out        string
+	}
+
+	// sink is where the fetched games end up
+	sink interface {
+		PutScoreboard(context.Context, nba.GetScoreboardCommand, nba.ScoreboardData) error
+		PutBoxscore(context.Context, nba.GetBoxscoreCommand, nba.BoxscoreData) error
+		Close() error
+	}
+
+	// rewinder is a sink appending to files, which a crash can leave with part
+	// of a date. The checkpoint records where they ended after the last
+	// complete date, so a resumed run starts from there instead of writing the
+	// date twice. Sinks keyed by game, like the store, overwrite it instead.
+	rewinder interface {
+		offsets() (map[string]int64, error)
+		rewind(map[string]int64) error
+	}
+)
+
+func main() {
+	l, _ := zap.NewProduction()
+	defer l.Sync() //nolint: errcheck
+
+	logger := l.Sugar()
+
+	var opts options
+
+	flag.StringVar(&opts.league, "league", "nba", "league to backfill, like nba, wnba or gleague")
+	flag.StringVar(&opts.from, "from", "", "first date to backfill (YYYY-MM-DD)")
+	flag.StringVar(&opts.to, "to", "", "last date to backfill (YYYY-MM-DD), defaults to yesterday")
+	flag.StringVar(&opts.season, "season", "", "whole season to backfill (e.g. 2022-23 or 2022), instead of from/to")
+	flag.DurationVar(&opts.rate, "rate", time.Second, "minimum interval between requests to each upstream host")
+	flag.StringVar(&opts.checkpoint, "checkpoint", "backfill.checkpoint.json", "file where progress is saved to resume from")
+	flag.StringVar(&opts.db, "db", "", "path of the storage database to write to")
+	flag.StringVar(&opts.out, "out", "", "directory to write NDJSON files to")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, logger, opts); err != nil {
+		logger.Fatal(err)
+	}
+}
+
+func run(ctx context.Context, logger *zap.SugaredLogger, opts options) error {
+	var cfg config
+	if err := envconfig.Process("", &cfg); err != nil {
+		return fmt.Errorf("failed to load the env vars: %w", err)
+	}
+
+	reg, err := leagues(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load leagues: %w", err)
+	}
+
+	l := reg.ByName(opts.league)
+	if l.Name != opts.league {
+		return fmt.Errorf("unknown league %q", opts.league)
+	}
+
+	league := l.ID
+
+	from, to, err := dateRange(league, opts)
+	if err != nil {
+		return err
+	}
+
+	out, err := openSink(opts)
+	if err != nil {
+		return err
+	}
+
+	defer out.Close()
+
+	saved, err := loadCheckpoint(opts.checkpoint)
+	if err != nil {
+		return err
+	}
+
+	cp := newCheckpoint(league, opts, from)
+
+	if saved.resumes(cp) {
+		logger.Infow("resuming from checkpoint", "last_date", saved.LastDate, "done", len(saved.Done))
+
+		cp = saved
+		from = cp.next()
+
+		if rw, ok := out.(rewinder); ok {
+			if err := rw.rewind(cp.Offsets); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Saved before the first date, so a crash in it is rewound too.
+	if err := save(out, &cp, opts.checkpoint); err != nil {
+		return err
+	}
+
+	limits, err := upstreamLimits(opts.rate, l.StatsBaseURL, l.CDNBaseURL)
+	if err != nil {
+		return err
+	}
+
+	var (
+		n = nba.New(
+			gateway.NewClientWithTimeout(cfg.NBA.Timeout),
+			reg,
+			nba.WithLimiter(gateway.NewLimiter(limits)),
+		)
+		b = backfiller{
+			logger: logger,
+			api:    n,
+			out:    out,
+			now:    time.Now,
+		}
+	)
+
+	// The upstream is paced like the server paces its jobs and polls.
+	ctx = gateway.WithPriority(ctx, gateway.PriorityBackground)
+
+	logger.Infow("starting backfill", "league", league, "from", from.Format(dateFormat), "to", to.Format(dateFormat))
+
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		if cp.done(d) {
+			continue
+		}
+
+		complete, err := b.backfillDate(ctx, league, d)
+		if err != nil {
+			return fmt.Errorf("failed to backfill %s: %w", d.Format(dateFormat), err)
+		}
+
+		if !complete {
+			continue
+		}
+
+		cp.complete(d)
+
+		if err := save(out, &cp, opts.checkpoint); err != nil {
+			return err
+		}
+	}
+
+	if !cp.next().After(to) {
+		logger.Warnw("backfill completed with unfinished dates, run it again to retry them", "games", b.games, "from", cp.next().Format(dateFormat))
+
+		return nil
+	}
+
+	logger.Infow("backfill completed", "games", b.games)
+
+	return nil
+}
+
+type backfiller struct {
+	logger *zap.SugaredLogger
+	api    nba.API
+	out    sink
+	now    func() time.Time
+
+	games int
+}
+
+// backfillDate writes the scoreboard of the day and the boxscore of every
+// game in it. Dates with games that are not over yet are skipped, and not
+// complete. Games never played are left out of the boxscores.
+func (b *backfiller) backfillDate(ctx context.Context, league nba.LeagueID, d time.Time) (bool, error) {
+	sbCmd := nba.GetScoreboardCommand{Date: d.Format(dateFormat), LeagueID: league}
+
+	sb, err := b.api.GetScoreboard(ctx, sbCmd)
+	if err != nil {
+		return false, err
+	}
+
+	if len(sb.Scoreboard.Games) == 0 {
+		return true, nil
+	}
+
+	for _, g := range sb.Scoreboard.Games {
+		if g.Status != nba.Final && !b.unplayed(g) {
+			b.logger.Warnw("skipping date with unfinished games", "date", sbCmd.Date)
+
+			return false, nil
+		}
+	}
+
+	for _, g := range sb.Scoreboard.Games {
+		if g.Status != nba.Final {
+			b.logger.Warnw("skipping game never played", "date", sbCmd.Date, "game_id", g.ID, "status", g.Status)
+
+			continue
+		}
+
+		bsCmd := nba.GetBoxscoreCommand{GameID: g.ID, LeagueID: league}
+
+		bs, err := b.api.GetBoxscore(ctx, bsCmd)
+		if err != nil {
+			return false, fmt.Errorf("failed to get boxscore %s: %w", g.ID, err)
+		}
+
+		if err := b.out.PutBoxscore(ctx, bsCmd, bs); err != nil {
+			return false, err
+		}
+
+		b.games++
+	}
+
+	// The scoreboard goes last so a date is only complete once all its boxscores are.
+	if err := b.out.PutScoreboard(ctx, sbCmd, sb); err != nil {
+		return false, err
+	}
+
+	b.logger.Infow("backfilled date", "date", sbCmd.Date, "games", len(sb.Scoreboard.Games))
+
+	return true, nil
+}
+
+// save records where the files of the sink end, if it is a rewinder, and
+// saves the checkpoint
+func save(out sink, cp *checkpoint, path string) error {
+	if rw, ok := out.(rewinder); ok {
+		offsets, err := rw.offsets()
+		if err != nil {
+			return err
+		}
+
+		cp.Offsets = offsets
+	}
+
+	return cp.save(path)
+}
+
+// unplayed reports whether the game should have been over long ago
+func (b *backfiller) unplayed(g nba.Game) bool {
+	return b.now().Sub(time.Time(g.StartsAt)) > unplayedAfter
+}
+
+func openSink(opts options) (sink, error) {
+	switch {
+	case opts.db != "" && opts.out != "":
+		return nil, errors.New("only one of -db and -out can be set")
+	case opts.db != "":
+		return storage.Open(opts.db)
+	case opts.out != "":
+		return newNDJSONSink(opts.out)
+	default:
+		return nil, errors.New("one of -db or -out is required")
+	}
+}
+
+// leagues builds the league registry from the defaults, on the configured
+// URLs, and the leagues file
+func leagues(cfg config) (*nba.Registry, error) {
+	ll := nba.DefaultLeagues(cfg.NBA.BaseURL, cfg.NBA.CDNBaseURL, cfg.WNBA.CDNBaseURL)
+
+	if cfg.NBA.LeaguesFile != "" {
+		var err error
+		if ll, err = nba.LoadLeagues(cfg.NBA.LeaguesFile, ll); err != nil {
+			return nil, err
+		}
+	}
+
+	return nba.NewRegistry(ll)
+}
+
+// upstreamLimits paces every host of the upstream urls to a request per
+// interval. Without an interval they are not paced.
+func upstreamLimits(interval time.Duration, urls ...string) (map[string]gateway.HostLimit, error) {
+	if interval <= 0 {
+		return nil, nil
+	}
+
+	limits := make(map[string]gateway.HostLimit, len(urls))
+
+	for _, u := range urls {
+		parsed, err := url.Parse(u)
+		if err != nil {
+			return nil, fmt.Errorf("invalid upstream url %q: %w", u, err)
+		}
+
+		limits[parsed.Host] = gateway.HostLimit{PerSecond: 1 / interval.Seconds(), Burst: 1}
+	}
+
+	return limits, nil
+}
+
+// dateRange resolves the dates to backfill from either the season or the
+// from/to flags.
+func dateRange(league nba.LeagueID, opts options) (time.Time, time.Time, error) {
+	if opts.season != "" {
+		return seasonRange(league, opts.season)
+	}
+
+	if opts.from == "" {
+		return time.Time{}, time.Time{}, errors.New("either -season or -from is required")
+	}
+
+	from, err := time.Parse(dateFormat, opts.from)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid -from: %w", err)
+	}
+
+	to := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -1)
+
+	if opts.to != "" {
+		if to, err = time.Parse(dateFormat, opts.to); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid -to: %w", err)
+		}
+	}
+
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, errors.New("-to is before -from")
+	}
+
+	return from, to, nil
+}
+
+// seasonRange covers preseason to finals. NBA seasons are named after the year
+// they start in (2022-23), WNBA seasons fit in a single year.
+func seasonRange(league nba.LeagueID, season string) (time.Time, time.Time, error) {
+	if len(season) < 4 {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid -season %q", season)
+	}
+
+	year, err := strconv.Atoi(season[:4])
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid -season %q: %w", season, err)
+	}
+
+	if league == nba.WNBA {
+		return time.Date(year, time.April, 15, 0, 0, 0, 0, time.UTC),
+			time.Date(year, time.October, 31, 0, 0, 0, 0, time.UTC), nil
+	}
+
+	return time.Date(year, time.September, 25, 0, 0, 0, 0, time.UTC),
+		time.Date(year+1, time.June, 30, 0, 0, 0, 0, time.UTC), nil
+}
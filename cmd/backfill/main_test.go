@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestDateRange(t *testing.T) {
+	t.Parallel()
+
+	yesterday := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -1)
+
+	tests := []struct {
+		scenario string
+
+		opts options
+
+		expFrom string
+		expTo   string
+		expErr  string
+	}{
+		{scenario: "from and to", opts: options{from: "2022-10-18", to: "2022-10-20"}, expFrom: "2022-10-18", expTo: "2022-10-20"},
+		{scenario: "up to yesterday", opts: options{from: "2022-10-18"}, expFrom: "2022-10-18", expTo: yesterday.Format(dateFormat)},
+		{scenario: "season", opts: options{season: "2022-23", from: "2020-01-01"}, expFrom: "2022-09-25", expTo: "2023-06-30"},
+		{scenario: "missing from", opts: options{}, expErr: "either -season or -from is required"},
+		{scenario: "invalid from", opts: options{from: "18/10/2022"}, expErr: "invalid -from"},
+		{scenario: "invalid to", opts: options{from: "2022-10-18", to: "tomorrow"}, expErr: "invalid -to"},
+		{scenario: "to before from", opts: options{from: "2022-10-18", to: "2022-10-17"}, expErr: "-to is before -from"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			from, to, err := dateRange(nba.NBA, tt.opts)
+			if tt.expErr != "" {
+				assert.ErrorContains(t, err, tt.expErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expFrom, from.Format(dateFormat))
+			assert.Equal(t, tt.expTo, to.Format(dateFormat))
+		})
+	}
+}
+
+func TestUpstreamLimits(t *testing.T) {
+	t.Parallel()
+
+	limits, err := upstreamLimits(500*time.Millisecond, "https://stats.nba.com", "https://cdn.nba.com", "https://cdn.wnba.com")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]gateway.HostLimit{
+		"stats.nba.com": {PerSecond: 2, Burst: 1},
+		"cdn.nba.com":   {PerSecond: 2, Burst: 1},
+		"cdn.wnba.com":  {PerSecond: 2, Burst: 1},
+	}, limits)
+
+	limits, err = upstreamLimits(0, "https://stats.nba.com")
+	require.NoError(t, err)
+	assert.Empty(t, limits)
+
+	_, err = upstreamLimits(time.Second, "://stats.nba.com")
+	assert.ErrorContains(t, err, "invalid upstream url")
+}
+
+func TestSeasonRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		scenario string
+
+		league nba.LeagueID
+		season string
+
+		expFrom string
+		expTo   string
+		expErr  bool
+	}{
+		{scenario: "nba", league: nba.NBA, season: "2022-23", expFrom: "2022-09-25", expTo: "2023-06-30"},
+		{scenario: "nba by year", league: nba.NBA, season: "2022", expFrom: "2022-09-25", expTo: "2023-06-30"},
+		{scenario: "wnba", league: nba.WNBA, season: "2023", expFrom: "2023-04-15", expTo: "2023-10-31"},
+		{scenario: "too short", league: nba.NBA, season: "22", expErr: true},
+		{scenario: "not a year", league: nba.NBA, season: "last", expErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			from, to, err := seasonRange(tt.league, tt.season)
+			if tt.expErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expFrom, from.Format(dateFormat))
+			assert.Equal(t, tt.expTo, to.Format(dateFormat))
+		})
+	}
+}
+
+// memorySink keeps the ids of the games written to it
+type memorySink struct {
+	boxscores   []string
+	scoreboards []string
+}
+
+func (s *memorySink) PutScoreboard(_ context.Context, cmd nba.GetScoreboardCommand, _ nba.ScoreboardData) error {
+	s.scoreboards = append(s.scoreboards, cmd.Date)
+
+	return nil
+}
+
+func (s *memorySink) PutBoxscore(_ context.Context, cmd nba.GetBoxscoreCommand, _ nba.BoxscoreData) error {
+	s.boxscores = append(s.boxscores, cmd.GameID)
+
+	return nil
+}
+
+func (s *memorySink) Close() error { return nil }
+
+func TestBackfillDate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		day   = time.Date(2022, 10, 18, 0, 0, 0, 0, time.UTC)
+		start = nba.GameTime(day.Add(23*time.Hour + 30*time.Minute))
+	)
+
+	tests := []struct {
+		scenario string
+
+		now   time.Time
+		games []nba.Game
+
+		expComplete  bool
+		expBoxscores []string
+	}{
+		{
+			scenario:     "final games",
+			now:          day.AddDate(0, 0, 1),
+			games:        []nba.Game{{ID: "0022200001", Status: nba.Final, StartsAt: start}},
+			expComplete:  true,
+			expBoxscores: []string{"0022200001"},
+		},
+		{
+			scenario: "game not over yet",
+			now:      day.AddDate(0, 0, 1),
+			games: []nba.Game{
+				{ID: "0022200001", Status: nba.Final, StartsAt: start},
+				{ID: "0022200002", Status: nba.Live, StartsAt: start},
+			},
+		},
+		{
+			scenario: "postponed game",
+			now:      day.AddDate(0, 0, 3),
+			games: []nba.Game{
+				{ID: "0022200001", Status: nba.Final, StartsAt: start},
+				{ID: "0022200002", Status: nba.Scheduled, StartsAt: start},
+			},
+			expComplete:  true,
+			expBoxscores: []string{"0022200001"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			api := new(nba.APIMock)
+			api.On("GetScoreboard", mock.Anything, nba.GetScoreboardCommand{Date: "2022-10-18", LeagueID: nba.NBA}).
+				Return(nba.ScoreboardData{Scoreboard: nba.Scoreboard{Games: tt.games}}, nil)
+			api.On("GetBoxscore", mock.Anything, mock.Anything).Return(nba.BoxscoreData{}, nil)
+
+			var (
+				out = &memorySink{}
+				b   = backfiller{logger: zap.NewNop().Sugar(), api: api, out: out, now: func() time.Time { return tt.now }}
+			)
+
+			complete, err := b.backfillDate(context.Background(), nba.NBA, day)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expComplete, complete)
+			assert.Equal(t, tt.expBoxscores, out.boxscores)
+
+			if tt.expComplete {
+				assert.Equal(t, []string{"2022-10-18"}, out.scoreboards)
+			}
+		})
+	}
+}
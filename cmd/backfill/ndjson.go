@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+)
+
+// ndjsonSink appends every scoreboard and boxscore as one JSON document per
+// line, to scoreboards.ndjson and boxscores.ndjson respectively. It is a
+// rewinder: a resumed run drops whatever was written past the checkpoint.
+type ndjsonSink struct {
+	scoreboards *os.File
+	boxscores   *os.File
+}
+
+// The lines have their own shape, as the gateway types marshal for the API:
+// start times in RFC 3339 instead of the hour alone, so they decode back.
+type (
+	scoreboardLine struct {
+		LeagueID nba.LeagueID `json:"league_id"`
+		Date     string       `json:"date"`
+		Games    []gameLine   `json:"games"`
+	}
+
+	gameLine struct {
+		ID       string         `json:"game_id"`
+		Status   nba.GameStatus `json:"status"`
+		StartsAt time.Time      `json:"starts_at"`
+		HomeTeam nba.Team       `json:"home_team"`
+		AwayTeam nba.Team       `json:"away_team"`
+	}
+
+	boxscoreLine struct {
+		LeagueID nba.LeagueID   `json:"league_id"`
+		GameID   string         `json:"game_id"`
+		Status   nba.GameStatus `json:"status"`
+		HomeTeam nba.Team       `json:"home_team"`
+		AwayTeam nba.Team       `json:"away_team"`
+	}
+)
+
+func newNDJSONSink(dir string) (*ndjsonSink, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	sb, err := openAppend(filepath.Join(dir, "scoreboards.ndjson"))
+	if err != nil {
+		return nil, err
+	}
+
+	bs, err := openAppend(filepath.Join(dir, "boxscores.ndjson"))
+	if err != nil {
+		sb.Close()
+
+		return nil, err
+	}
+
+	return &ndjsonSink{scoreboards: sb, boxscores: bs}, nil
+}
+
+func openAppend(path string) (*os.File, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+
+	return f, nil
+}
+
+func (s *ndjsonSink) PutScoreboard(_ context.Context, cmd nba.GetScoreboardCommand, sb nba.ScoreboardData) error {
+	line := scoreboardLine{LeagueID: cmd.LeagueID, Date: cmd.Date, Games: make([]gameLine, len(sb.Scoreboard.Games))}
+
+	for i, g := range sb.Scoreboard.Games {
+		line.Games[i] = gameLine{
+			ID:       g.ID,
+			Status:   g.Status,
+			StartsAt: time.Time(g.StartsAt).UTC(),
+			HomeTeam: g.HomeTeam,
+			AwayTeam: g.AwayTeam,
+		}
+	}
+
+	return writeLine(s.scoreboards, line)
+}
+
+func (s *ndjsonSink) PutBoxscore(_ context.Context, cmd nba.GetBoxscoreCommand, bs nba.BoxscoreData) error {
+	return writeLine(s.boxscores, boxscoreLine{
+		LeagueID: cmd.LeagueID,
+		GameID:   cmd.GameID,
+		Status:   bs.Boxscore.Status,
+		HomeTeam: bs.Boxscore.HomeTeam,
+		AwayTeam: bs.Boxscore.AwayTeam,
+	})
+}
+
+// offsets are the sizes of the files, by name
+func (s *ndjsonSink) offsets() (map[string]int64, error) {
+	offsets := make(map[string]int64, 2)
+
+	for _, f := range []*os.File{s.scoreboards, s.boxscores} {
+		info, err := f.Stat()
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat %s: %w", f.Name(), err)
+		}
+
+		offsets[filepath.Base(f.Name())] = info.Size()
+	}
+
+	return offsets, nil
+}
+
+// rewind truncates the files to the offsets, dropping the lines of a date a
+// crash left half written. The next lines are appended from there.
+func (s *ndjsonSink) rewind(offsets map[string]int64) error {
+	for _, f := range []*os.File{s.scoreboards, s.boxscores} {
+		off, ok := offsets[filepath.Base(f.Name())]
+		if !ok {
+			continue
+		}
+
+		if err := f.Truncate(off); err != nil {
+			return fmt.Errorf("failed to rewind %s: %w", f.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+func (s *ndjsonSink) Close() error {
+	return errors.Join(s.scoreboards.Close(), s.boxscores.Close())
+}
+
+func writeLine(f *os.File, v any) error {
+	// Encode appends the trailing new line.
+	if err := json.NewEncoder(f).Encode(v); err != nil {
+		return fmt.Errorf("failed to write %s: %w", f.Name(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNDJSONSink(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx  = context.Background()
+		dir  = t.TempDir()
+		at   = time.Date(2022, 10, 18, 23, 30, 0, 0, time.UTC)
+		home = nba.Team{ID: 1610612738, Name: "Celtics", Tricode: "BOS", Stats: nba.Stats{Minutes: "PT240M00.00S", PT: 126}}
+		away = nba.Team{ID: 1610612755, Name: "76ers", Tricode: "PHI", Stats: nba.Stats{PT: 117}}
+	)
+
+	s, err := newNDJSONSink(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, s.PutBoxscore(ctx, nba.GetBoxscoreCommand{GameID: "0022200001", LeagueID: nba.NBA}, nba.BoxscoreData{
+		Boxscore: nba.Boxscore{ID: "0022200001", Status: nba.Final, HomeTeam: home, AwayTeam: away},
+	}))
+	require.NoError(t, s.PutScoreboard(ctx, nba.GetScoreboardCommand{Date: "2022-10-18", LeagueID: nba.NBA}, nba.ScoreboardData{
+		Scoreboard: nba.Scoreboard{GameDate: nba.GameDate(at), Games: []nba.Game{
+			{ID: "0022200001", Status: nba.Final, StartsAt: nba.GameTime(at), HomeTeam: home, AwayTeam: away},
+		}},
+	}))
+	require.NoError(t, s.Close())
+
+	var sb scoreboardLine
+	readLine(t, filepath.Join(dir, "scoreboards.ndjson"), &sb)
+
+	assert.Equal(t, scoreboardLine{LeagueID: nba.NBA, Date: "2022-10-18", Games: []gameLine{
+		{ID: "0022200001", Status: nba.Final, StartsAt: at, HomeTeam: home, AwayTeam: away},
+	}}, sb, "the start time keeps its date")
+
+	var bs boxscoreLine
+	readLine(t, filepath.Join(dir, "boxscores.ndjson"), &bs)
+
+	assert.Equal(t, boxscoreLine{LeagueID: nba.NBA, GameID: "0022200001", Status: nba.Final, HomeTeam: home, AwayTeam: away}, bs)
+}
+
+// readLine decodes the file, which must have a single line
+func readLine(t *testing.T, path string, v any) {
+	t.Helper()
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	sc.Buffer(nil, 1<<20)
+
+	var n int
+	for sc.Scan() {
+		require.NoError(t, json.Unmarshal(sc.Bytes(), v))
+
+		n++
+	}
+
+	require.NoError(t, sc.Err())
+	assert.Equal(t, 1, n)
+}
+
+func TestNDJSONSinkRewind(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx = context.Background()
+		dir = t.TempDir()
+	)
+
+	put := func(s *ndjsonSink, gameID string) {
+		require.NoError(t, s.PutBoxscore(ctx, nba.GetBoxscoreCommand{GameID: gameID, LeagueID: nba.NBA}, nba.BoxscoreData{
+			Boxscore: nba.Boxscore{ID: gameID, Status: nba.Final},
+		}))
+	}
+
+	s, err := newNDJSONSink(dir)
+	require.NoError(t, err)
+
+	put(s, "0022200001")
+
+	offsets, err := s.offsets()
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), offsets["scoreboards.ndjson"])
+
+	// The run crashes half way through the next date.
+	put(s, "0022200002")
+	require.NoError(t, s.Close())
+
+	s, err = newNDJSONSink(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, s.rewind(offsets))
+	put(s, "0022200002")
+	require.NoError(t, s.Close())
+
+	data, err := os.ReadFile(filepath.Join(dir, "boxscores.ndjson"))
+	require.NoError(t, err)
+
+	var ids []string
+	for _, line := range bytes.Split(bytes.TrimSpace(data), []byte("\n")) {
+		var bs boxscoreLine
+		require.NoError(t, json.Unmarshal(line, &bs))
+
+		ids = append(ids, bs.GameID)
+	}
+
+	assert.Equal(t, []string{"0022200001", "0022200002"}, ids, "the half written date isn't duplicated")
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba/nbatest"
+	"go.uber.org/zap"
+)
+
+func main() {
+	l, _ := zap.NewProduction()
+	defer l.Sync() //nolint: errcheck
+
+	logger := l.Sugar()
+
+	var (
+		addr     = flag.String("addr", "127.0.0.1:8081", "address to listen on")
+		dir      = flag.String("fixtures", "", "directory with fixtures, defaults to the embedded ones")
+		live     = flag.String("live", "", "game id to replay as live from startup")
+		duration = flag.Duration("live-duration", 10*time.Minute, "how long the live game takes to finish")
+		faults   nbatest.Faults
+	)
+
+	flag.DurationVar(&faults.Latency, "latency", 0, "latency added to every response")
+	flag.Float64Var(&faults.ForbiddenRate, "forbidden-rate", 0, "probability of answering 403")
+	flag.Float64Var(&faults.ThrottledRate, "throttled-rate", 0, "probability of answering 429")
+	flag.Float64Var(&faults.MalformedRate, "malformed-rate", 0, "probability of answering malformed JSON")
+	flag.Parse()
+
+	opts := []nbatest.Option{nbatest.WithFaults(faults)}
+	if *dir != "" {
+		opts = append(opts, nbatest.WithFixtures(os.DirFS(*dir)))
+	}
+
+	s := nbatest.New(opts...)
+	if *live != "" {
+		s.StartLive(*live, time.Now(), *duration)
+	}
+
+	server := &http.Server{Addr: *addr, Handler: s, ReadHeaderTimeout: 5 * time.Second}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+		server.Shutdown(context.Background()) //nolint: errcheck
+	}()
+
+	logger.Infow("serving fake NBA upstream", "addr", *addr, "hint", "set NBA_BASE_URL, NBA_CDN_BASE_URL and WNBA_CDN_BASE_URL to http://"+*addr)
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Fatal(err)
+	}
+}
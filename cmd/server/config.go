@@ -0,0 +1,514 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/http/rest"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/logging"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/ratelimit"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/tracing"
+	"gopkg.in/yaml.v3"
+)
+
+// config is read from the env vars, over the optional CONFIG_FILE
+type config struct {
+	// Debug lowers the log level to debug, logging every upstream call.
+	Debug bool
+	// LogLevel is debug, info, warn or error, and overrides Debug.
+	LogLevel string `split_words:"true"`
+	// LogFormat is json or console.
+	LogFormat   string `split_words:"true"`
+	LogSampling bool   `split_words:"true"`
+	// ConfigPollInterval is how often CONFIG_FILE is checked for changes.
+	// Zero only reloads it on SIGHUP.
+	ConfigPollInterval time.Duration `split_words:"true"`
+	Admin              struct {
+		// Token guards the admin endpoints and /metrics, disabled when empty.
+		Token string
+	}
+	Web struct {
+		APIHost         string        `split_words:"true"`
+		ReadTimeout     time.Duration `split_words:"true"`
+		WriteTimeout    time.Duration `split_words:"true"`
+		IdleTimeout     time.Duration `split_words:"true"`
+		ShutdownTimeout time.Duration `split_words:"true"`
+		// DrainDelay is how long readiness fails before the server shuts down,
+		// for the load balancer to stop sending requests.
+		DrainDelay time.Duration `split_words:"true"`
+	}
+	CORS struct {
+		// AllowedOrigins may have a * wildcard, like https://*.example.com.
+		AllowedOrigins   []string      `split_words:"true"`
+		AllowedMethods   []string      `split_words:"true"`
+		AllowedHeaders   []string      `split_words:"true"`
+		AllowCredentials bool          `split_words:"true"`
+		MaxAge           time.Duration `split_words:"true"`
+		// Partner overrides the origins and credentials of the paths under
+		// Routes, like /stats, for partner sites calling with their API key.
+		Partner struct {
+			Routes           []string
+			AllowedOrigins   []string `split_words:"true"`
+			AllowCredentials bool     `split_words:"true"`
+		}
+	}
+	Cache struct {
+		ScheduledMaxAge time.Duration `split_words:"true"`
+		LiveMaxAge      time.Duration `split_words:"true"`
+		// FinalMaxAge of zero marks finished games as immutable.
+		FinalMaxAge time.Duration `split_words:"true"`
+	}
+	RateLimit struct {
+		// Anonymous clients are limited per IP.
+		AnonymousPerMinute int `split_words:"true"`
+		AnonymousBurst     int `split_words:"true"`
+		// ClientIPHeader is set by the proxy in front of the API, e.g. Fly-Client-IP.
+		ClientIPHeader string `split_words:"true"`
+		// TrustedProxies are the addresses or CIDRs allowed to set ClientIPHeader.
+		TrustedProxies []string `split_words:"true"`
+		// KeysFile is a JSON file with tiers and API keys.
+		KeysFile string `split_words:"true"`
+		// APIKeys are client:tier:key entries, added to the ones in KeysFile.
+		APIKeys []string `envconfig:"API_KEYS"`
+	}
+	Tracing struct {
+		// Exporter is otlp, stdout or none. OTLP is configured with the
+		// standard OTEL_EXPORTER_OTLP_* env vars.
+		Exporter    tracing.Exporter
+		ServiceName string `split_words:"true"`
+	}
+	GRPC struct {
+		Host string
+	}
+	NBA struct {
+		CDNBaseURL string `split_words:"true"`
+		BaseURL    string `split_words:"true"`
+		Timeout    time.Duration
+		// Transport is live, record or replay. Recordings live in RecordingsDir.
+		Transport     gateway.Mode
+		RecordingsDir string `split_words:"true"`
+		// Requests per second to stats.nba.com and the CDNs. Zero disables pacing.
+		StatsRate  float64 `split_words:"true"`
+		StatsBurst int     `split_words:"true"`
+		CDNRate    float64 `split_words:"true"`
+		CDNBurst   int     `split_words:"true"`
+		// LeaguesFile is a JSON list of leagues, added to the NBA, WNBA, G
+		// League and Summer League or replacing them by id.
+		LeaguesFile string `split_words:"true"`
+	}
+
+	WNBA struct {
+		CDNBaseURL string `split_words:"true"`
+	}
+
+	Storage struct {
+		// Path of the database file. Final games are only persisted when it is set.
+		Path string
+	}
+
+	Providers struct {
+		// FileLeagues are served from the JSON files in FilesDir, in a
+		// directory per league, instead of the NBA APIs.
+		FileLeagues []string `split_words:"true"`
+		FilesDir    string   `split_words:"true"`
+	}
+}
+
+// defaultConfig is the config before the file and env vars apply
+func defaultConfig() config {
+	var cfg config
+
+	cfg.LogFormat = "json"
+	cfg.LogSampling = true
+	cfg.ConfigPollInterval = 10 * time.Second
+
+	cfg.Web.APIHost = "0.0.0.0:8080"
+	cfg.Web.ReadTimeout = 30 * time.Second
+	cfg.Web.WriteTimeout = 2 * time.Minute
+	cfg.Web.IdleTimeout = 5 * time.Second
+	cfg.Web.ShutdownTimeout = 30 * time.Second
+	cfg.Web.DrainDelay = 5 * time.Second
+
+	cfg.CORS.AllowedOrigins = []string{"https://*pedromealha.dev", "http://localhost*"}
+	cfg.CORS.AllowedMethods = []string{"GET", "POST", "OPTIONS"}
+	cfg.CORS.AllowedHeaders = []string{"Accept", "Content-Type", "If-None-Match", "X-API-Key", "X-Request-ID", "Accept-Timezone"}
+	cfg.CORS.AllowCredentials = true
+	cfg.CORS.MaxAge = 5 * time.Minute
+
+	cfg.Cache.ScheduledMaxAge = time.Minute
+	cfg.Cache.LiveMaxAge = 5 * time.Second
+
+	cfg.RateLimit.AnonymousPerMinute = 60
+	cfg.RateLimit.AnonymousBurst = 10
+
+	cfg.Tracing.Exporter = tracing.ExporterNone
+	cfg.Tracing.ServiceName = "nba-stats-api"
+
+	cfg.GRPC.Host = "0.0.0.0:9090"
+
+	cfg.NBA.Timeout = 120 * time.Second
+	cfg.NBA.Transport = gateway.ModeLive
+	cfg.NBA.RecordingsDir = "recordings"
+	cfg.NBA.StatsRate = 2
+	cfg.NBA.StatsBurst = 5
+	cfg.NBA.CDNRate = 10
+	cfg.NBA.CDNBurst = 20
+
+	cfg.Providers.FilesDir = "data"
+
+	return cfg
+}
+
+// configLoader reads the config from the env vars, layered over a YAML file
+// over the defaults. The file has a section per struct of the config, with
+// snake case keys, so
+//
+//	nba:
+//	  base_url: https://stats.nba.com
+//
+// is NBA_BASE_URL. Env vars win over the file. The process env is only read,
+// so a rejected file leaves nothing behind.
+type configLoader struct {
+	path string
+}
+
+func newConfigLoader(path string) *configLoader {
+	return &configLoader{path: path}
+}
+
+// load reads the file again and the config with it, validated
+func (l *configLoader) load() (config, error) {
+	cfg := defaultConfig()
+
+	if l.path != "" {
+		if err := l.readFile(&cfg); err != nil {
+			return config{}, err
+		}
+	}
+
+	if err := envconfig.Process("", &cfg); err != nil {
+		return config{}, fmt.Errorf("failed to load the env vars: %w", err)
+	}
+
+	if err := cfg.validate(); err != nil {
+		return config{}, fmt.Errorf("invalid config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// readFile decodes the file into cfg. Unknown keys are rejected, so typos
+// don't go unnoticed.
+func (l *configLoader) readFile(cfg *config) error {
+	data, err := os.ReadFile(l.path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", l.path, err)
+	}
+
+	if doc.Kind == 0 {
+		return nil
+	}
+
+	// The fields are matched by their lower case names, without the
+	// underscores of the keys.
+	normalizeKeys(&doc)
+
+	normalized, err := yaml.Marshal(&doc)
+	if err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", l.path, err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(normalized))
+	dec.KnownFields(true)
+
+	if err := dec.Decode(cfg); err != nil {
+		// The anonymous structs of the config make for unreadable type names.
+		var te *yaml.TypeError
+		if errors.As(err, &te) {
+			for i, e := range te.Errors {
+				te.Errors[i], _, _ = strings.Cut(e, " in type ")
+			}
+		}
+
+		return fmt.Errorf("invalid config file %s: %w", l.path, err)
+	}
+
+	return nil
+}
+
+// normalizeKeys lower cases the keys of every mapping in n and drops their
+// underscores
+func normalizeKeys(n *yaml.Node) {
+	if n.Kind == yaml.MappingNode {
+		for i := 0; i < len(n.Content); i += 2 {
+			n.Content[i].Value = strings.ToLower(strings.ReplaceAll(n.Content[i].Value, "_", ""))
+		}
+	}
+
+	for _, c := range n.Content {
+		normalizeKeys(c)
+	}
+}
+
+// validate checks what envconfig can't, so a bad config fails at startup
+// instead of on the first request
+func (cfg config) validate() error {
+	var errs []error
+
+	for _, u := range []struct{ name, url string }{
+		{"NBA_BASE_URL", cfg.NBA.BaseURL},
+		{"NBA_CDN_BASE_URL", cfg.NBA.CDNBaseURL},
+		{"WNBA_CDN_BASE_URL", cfg.WNBA.CDNBaseURL},
+	} {
+		if parsed, err := url.Parse(u.url); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", u.name, err))
+		} else if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			errs = append(errs, fmt.Errorf("%s: %q is not an http(s) url", u.name, u.url))
+		}
+	}
+
+	for _, d := range []struct {
+		name     string
+		d        time.Duration
+		positive bool
+	}{
+		{"WEB_READ_TIMEOUT", cfg.Web.ReadTimeout, true},
+		{"WEB_WRITE_TIMEOUT", cfg.Web.WriteTimeout, true},
+		{"WEB_SHUTDOWN_TIMEOUT", cfg.Web.ShutdownTimeout, true},
+		{"NBA_TIMEOUT", cfg.NBA.Timeout, true},
+		{"WEB_IDLE_TIMEOUT", cfg.Web.IdleTimeout, false},
+		{"WEB_DRAIN_DELAY", cfg.Web.DrainDelay, false},
+		{"CACHE_SCHEDULED_MAX_AGE", cfg.Cache.ScheduledMaxAge, false},
+		{"CACHE_LIVE_MAX_AGE", cfg.Cache.LiveMaxAge, false},
+		{"CACHE_FINAL_MAX_AGE", cfg.Cache.FinalMaxAge, false},
+		{"CONFIG_POLL_INTERVAL", cfg.ConfigPollInterval, false},
+	} {
+		if d.d < 0 || (d.positive && d.d == 0) {
+			errs = append(errs, fmt.Errorf("%s: %s is not a sane duration", d.name, d.d))
+		}
+	}
+
+	if cfg.NBA.StatsRate < 0 || cfg.NBA.CDNRate < 0 || cfg.NBA.StatsBurst < 0 || cfg.NBA.CDNBurst < 0 {
+		errs = append(errs, errors.New("NBA upstream rates and bursts can't be negative"))
+	}
+
+	switch cfg.NBA.Transport {
+	case gateway.ModeLive, gateway.ModeRecord, gateway.ModeReplay:
+	default:
+		errs = append(errs, fmt.Errorf("NBA_TRANSPORT: unknown transport %q", cfg.NBA.Transport))
+	}
+
+	switch cfg.Tracing.Exporter {
+	case "", tracing.ExporterNone, tracing.ExporterOTLP, tracing.ExporterStdout:
+	default:
+		errs = append(errs, fmt.Errorf("TRACING_EXPORTER: unknown exporter %q", cfg.Tracing.Exporter))
+	}
+
+	if _, err := cfg.logging().ParseLevel(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if cfg.LogFormat != logging.FormatJSON && cfg.LogFormat != logging.FormatConsole {
+		errs = append(errs, fmt.Errorf("LOG_FORMAT: unknown format %q", cfg.LogFormat))
+	}
+
+	if err := corsPolicy(cfg).Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if limits, err := rateLimits(cfg); err != nil {
+		errs = append(errs, err)
+	} else if err := limits.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if reg, err := leagues(cfg); err != nil {
+		errs = append(errs, err)
+	} else if _, err := leagueProviders(cfg, reg); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// logging is the config of the logger
+func (cfg config) logging() logging.Config {
+	return logging.Config{
+		Level:    cfg.LogLevel,
+		Format:   cfg.LogFormat,
+		Sampling: cfg.LogSampling,
+		Debug:    cfg.Debug,
+	}
+}
+
+// restartOnly leaves out the settings applied on reload, to tell whether a new
+// config has changes that need a restart
+func (cfg config) restartOnly() config {
+	cfg.Debug, cfg.LogLevel = false, ""
+	cfg.CORS = config{}.CORS
+	cfg.Cache = config{}.Cache
+	cfg.RateLimit = config{}.RateLimit
+
+	return cfg
+}
+
+// upstreamLimits maps the stats and CDN hosts of the leagues to their limits
+func upstreamLimits(cfg config, leagues *nba.Registry) (map[string]gateway.HostLimit, error) {
+	var (
+		limits = make(map[string]gateway.HostLimit)
+		stats  = gateway.HostLimit{PerSecond: cfg.NBA.StatsRate, Burst: cfg.NBA.StatsBurst}
+		cdn    = gateway.HostLimit{PerSecond: cfg.NBA.CDNRate, Burst: cfg.NBA.CDNBurst}
+	)
+
+	for _, l := range leagues.All() {
+		for _, u := range []struct {
+			url   string
+			limit gateway.HostLimit
+		}{
+			{l.StatsBaseURL, stats},
+			{l.CDNBaseURL, cdn},
+		} {
+			parsed, err := url.Parse(u.url)
+			if err != nil {
+				return nil, fmt.Errorf("invalid upstream url %q: %w", u.url, err)
+			}
+
+			// When they share a host, like with the fake upstream, the first limit wins.
+			if _, ok := limits[parsed.Host]; !ok {
+				limits[parsed.Host] = u.limit
+			}
+		}
+	}
+
+	return limits, nil
+}
+
+// maskSecrets keeps API keys and the admin token out of the logs
+func maskSecrets(name string) *string {
+	if name != "APIKeys" && name != "Token" {
+		return nil
+	}
+
+	masked := "[redacted]"
+
+	return &masked
+}
+
+// rateLimits builds the rate limits from the defaults, the keys file and the
+// env vars, in that order.
+func rateLimits(cfg config) (ratelimit.Limits, error) {
+	limits := ratelimit.DefaultLimits()
+	limits.Anonymous = ratelimit.Tier{PerMinute: cfg.RateLimit.AnonymousPerMinute, Burst: cfg.RateLimit.AnonymousBurst}
+	limits.ClientIPHeader = cfg.RateLimit.ClientIPHeader
+	limits.TrustedProxies = cfg.RateLimit.TrustedProxies
+
+	if cfg.RateLimit.KeysFile != "" {
+		var err error
+		if limits, err = ratelimit.LoadLimits(cfg.RateLimit.KeysFile, limits); err != nil {
+			return ratelimit.Limits{}, err
+		}
+	}
+
+	for _, s := range cfg.RateLimit.APIKeys {
+		k, err := ratelimit.ParseAPIKey(s)
+		if err != nil {
+			return ratelimit.Limits{}, err
+		}
+
+		limits.Keys = append(limits.Keys, k)
+	}
+
+	return limits, nil
+}
+
+// leagues builds the league registry from the defaults, on the configured
+// URLs, and the leagues file
+func leagues(cfg config) (*nba.Registry, error) {
+	ll := nba.DefaultLeagues(cfg.NBA.BaseURL, cfg.NBA.CDNBaseURL, cfg.WNBA.CDNBaseURL)
+
+	if cfg.NBA.LeaguesFile != "" {
+		var err error
+		if ll, err = nba.LoadLeagues(cfg.NBA.LeaguesFile, ll); err != nil {
+			return nil, err
+		}
+	}
+
+	return nba.NewRegistry(ll)
+}
+
+// leagueProviders register the providers of the leagues not served by the NBA
+// APIs
+func leagueProviders(cfg config, reg *nba.Registry) ([]stats.Option, error) {
+	if len(cfg.Providers.FileLeagues) == 0 {
+		return nil, nil
+	}
+
+	if info, err := os.Stat(cfg.Providers.FilesDir); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("PROVIDERS_FILES_DIR: %q is not a directory", cfg.Providers.FilesDir)
+	}
+
+	var (
+		opts  []stats.Option
+		files = stats.NewFileProvider(os.DirFS(cfg.Providers.FilesDir), reg)
+	)
+
+	for _, name := range cfg.Providers.FileLeagues {
+		l := reg.ByName(name)
+		if l.Name != name {
+			return nil, fmt.Errorf("PROVIDERS_FILE_LEAGUES: unknown league %q", name)
+		}
+
+		opts = append(opts, stats.WithLeagueProvider(l.ID, files))
+	}
+
+	return opts, nil
+}
+
+// cachePolicy is the Cache-Control policy of the stats endpoints
+func cachePolicy(cfg config) rest.CachePolicy {
+	return rest.CachePolicy{
+		Scheduled: cfg.Cache.ScheduledMaxAge,
+		Live:      cfg.Cache.LiveMaxAge,
+		Final:     cfg.Cache.FinalMaxAge,
+	}
+}
+
+// corsPolicy is the CORS policy of the API, with the partner routes
+func corsPolicy(cfg config) rest.CORSPolicy {
+	p := rest.CORSPolicy{
+		AllowedOrigins:   cfg.CORS.AllowedOrigins,
+		AllowedMethods:   cfg.CORS.AllowedMethods,
+		AllowedHeaders:   cfg.CORS.AllowedHeaders,
+		AllowCredentials: cfg.CORS.AllowCredentials,
+		MaxAge:           cfg.CORS.MaxAge,
+	}
+
+	if len(cfg.CORS.Partner.Routes) == 0 {
+		return p
+	}
+
+	partner := p
+	partner.AllowedOrigins = cfg.CORS.Partner.AllowedOrigins
+	partner.AllowCredentials = cfg.CORS.Partner.AllowCredentials
+
+	p.Routes = make(map[string]rest.CORSPolicy, len(cfg.CORS.Partner.Routes))
+	for _, route := range cfg.CORS.Partner.Routes {
+		p.Routes[route] = partner
+	}
+
+	return p
+}
@@ -0,0 +1,359 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/http/rest"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const baseConfig = `
+nba:
+  base_url: https://stats.nba.com
+  cdn_base_url: https://cdn.nba.com
+wnba:
+  cdn_base_url: https://cdn.wnba.com
+`
+
+// newLoader writes the config file
+func newLoader(t *testing.T, content string) (*configLoader, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return newConfigLoader(path), path
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("CACHE_LIVE_MAX_AGE", "15s")
+
+	l, _ := newLoader(t, `
+nba:
+  base_url: https://stats.nba.com
+  cdn_base_url: https://cdn.nba.com
+  stats_rate: 0.5
+wnba:
+  cdn_base_url: https://cdn.wnba.com
+log_level: warn
+cache:
+  live_max_age: 1m
+  scheduled_max_age: 2m
+ratelimit:
+  anonymous_per_minute: 30
+  api_keys: [celtics:partner:secret]
+cors:
+  allowed_origins:
+    - https://example.com
+    - https://*.example.com
+`)
+
+	cfg, err := l.load()
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://stats.nba.com", cfg.NBA.BaseURL)
+	assert.Equal(t, "warn", cfg.LogLevel)
+	assert.Equal(t, 2*time.Minute, cfg.Cache.ScheduledMaxAge)
+	assert.Equal(t, 15*time.Second, cfg.Cache.LiveMaxAge, "env vars win over the file")
+	assert.Equal(t, 30, cfg.RateLimit.AnonymousPerMinute)
+	assert.Equal(t, []string{"celtics:partner:secret"}, cfg.RateLimit.APIKeys)
+	assert.Equal(t, []string{"https://example.com", "https://*.example.com"}, cfg.CORS.AllowedOrigins)
+	assert.Equal(t, 0.5, cfg.NBA.StatsRate)
+	assert.Equal(t, 10, cfg.RateLimit.AnonymousBurst, "defaults are kept")
+}
+
+func TestLoadConfigRemovedKeys(t *testing.T) {
+	l, path := newLoader(t, baseConfig+"log_level: warn\n")
+
+	cfg, err := l.load()
+	require.NoError(t, err)
+	assert.Equal(t, "warn", cfg.LogLevel)
+
+	require.NoError(t, os.WriteFile(path, []byte(baseConfig), 0o600))
+
+	cfg, err = l.load()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.LogLevel)
+}
+
+func TestLoadConfigKeepsEnv(t *testing.T) {
+	l, path := newLoader(t, baseConfig+"log_level: loud\n")
+
+	_, err := l.load()
+	require.Error(t, err)
+
+	_, set := os.LookupEnv("LOG_LEVEL")
+	assert.False(t, set, "a rejected file leaves nothing in the env")
+
+	require.NoError(t, os.WriteFile(path, []byte(baseConfig), 0o600))
+
+	cfg, err := l.load()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.LogLevel)
+}
+
+func TestLoadConfigInvalid(t *testing.T) {
+	tests := []struct {
+		scenario string
+
+		content string
+
+		expErr []string
+	}{
+		{
+			scenario: "unknown keys",
+			content:  baseConfig + "web:\n  api_hots: 0.0.0.0:80\nlogs: debug\n",
+			expErr:   []string{"field apihots not found", "field logs not found"},
+		},
+		{
+			scenario: "invalid yaml",
+			content:  "nba: [",
+			expErr:   []string{"failed to parse config file"},
+		},
+		{
+			scenario: "missing league cdn",
+			content:  "nba:\n  base_url: https://stats.nba.com\n  cdn_base_url: https://cdn.nba.com\n",
+			expErr:   []string{"WNBA_CDN_BASE_URL"},
+		},
+		{
+			scenario: "invalid values",
+			content: `
+nba:
+  base_url: stats.nba.com
+  cdn_base_url: https://cdn.nba.com
+  timeout: 0s
+  transport: fake
+wnba:
+  cdn_base_url: https://cdn.wnba.com
+web:
+  drain_delay: -1s
+log_level: loud
+log_format: xml
+ratelimit:
+  api_keys: [celtics:gold:secret]
+`,
+			expErr: []string{
+				`NBA_BASE_URL: "stats.nba.com" is not an http(s) url`,
+				"NBA_TIMEOUT: 0s is not a sane duration",
+				"WEB_DRAIN_DELAY: -1s is not a sane duration",
+				`NBA_TRANSPORT: unknown transport "fake"`,
+				`invalid log level "loud"`,
+				`LOG_FORMAT: unknown format "xml"`,
+				"gold",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.scenario, func(t *testing.T) {
+			l, _ := newLoader(t, tt.content)
+
+			_, err := l.load()
+			require.Error(t, err)
+
+			for _, e := range tt.expErr {
+				assert.ErrorContains(t, err, e)
+			}
+		})
+	}
+}
+
+func TestReload(t *testing.T) {
+	l, path := newLoader(t, baseConfig)
+
+	cfg, err := l.load()
+	require.NoError(t, err)
+
+	limits, err := rateLimits(cfg)
+	require.NoError(t, err)
+
+	rl, err := ratelimit.NewLimiter(limits)
+	require.NoError(t, err)
+
+	reg, err := leagues(cfg)
+	require.NoError(t, err)
+
+	var (
+		level = zap.NewAtomicLevelAt(zap.InfoLevel)
+		api   = rest.NewAPI(zap.NewNop().Sugar(), new(stats.ProviderMock), reg, rest.WithRateLimiter(rl))
+		r     = &reloader{logger: zap.NewNop().Sugar(), loader: l, cfg: cfg, level: level, api: api, rl: rl}
+	)
+
+	origin := func(origin string) string {
+		req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+		req.Header.Set("Origin", origin)
+
+		w := httptest.NewRecorder()
+		api.Routes().ServeHTTP(w, req)
+
+		return w.Header().Get("Access-Control-Allow-Origin")
+	}
+
+	require.NoError(t, os.WriteFile(path, []byte(baseConfig+`
+log_level: debug
+cors:
+  allowed_origins: [https://example.com]
+ratelimit:
+  api_keys: [celtics:partner:secret]
+web:
+  api_host: 0.0.0.0:80
+`), 0o600))
+
+	r.reload("test")
+
+	assert.Equal(t, zap.DebugLevel, level.Level())
+	assert.Equal(t, "https://example.com", origin("https://example.com"))
+	assert.Equal(t, []string{"celtics:partner:secret"}, r.cfg.RateLimit.APIKeys)
+
+	// A broken file keeps the running config.
+	require.NoError(t, os.WriteFile(path, []byte(baseConfig+"log_level: loud\n"), 0o600))
+
+	r.reload("test")
+
+	assert.Equal(t, zap.DebugLevel, level.Level())
+	assert.Equal(t, "debug", r.cfg.LogLevel)
+
+	// A level changed at runtime is kept when the config doesn't change it.
+	level.SetLevel(zap.ErrorLevel)
+
+	require.NoError(t, os.WriteFile(path, []byte(baseConfig+"log_level: debug\ncors:\n  allowed_origins: [https://other.com]\n"), 0o600))
+
+	r.reload("test")
+
+	assert.Equal(t, zap.ErrorLevel, level.Level())
+	assert.Equal(t, "https://other.com", origin("https://other.com"))
+}
+
+func TestExampleConfig(t *testing.T) {
+	_, err := newConfigLoader(filepath.Join("..", "..", "configs", "server.example.yaml")).load()
+	assert.NoError(t, err)
+}
+
+func TestCORSConfig(t *testing.T) {
+	l, _ := newLoader(t, baseConfig+`
+cors:
+  allowed_origins: ["*"]
+  allow_credentials: false
+  max_age: 1m
+  partner:
+    routes: [/stats, /graphql]
+    allowed_origins: [https://partner.com]
+    allow_credentials: true
+`)
+
+	cfg, err := l.load()
+	require.NoError(t, err)
+
+	p := corsPolicy(cfg)
+	assert.Equal(t, []string{"*"}, p.AllowedOrigins)
+	assert.False(t, p.AllowCredentials)
+	assert.Equal(t, time.Minute, p.MaxAge)
+	assert.Equal(t, []string{"GET", "POST", "OPTIONS"}, p.AllowedMethods)
+
+	for _, route := range []string{"/stats", "/graphql"} {
+		assert.Equal(t, []string{"https://partner.com"}, p.Routes[route].AllowedOrigins)
+		assert.True(t, p.Routes[route].AllowCredentials)
+		assert.Equal(t, p.AllowedHeaders, p.Routes[route].AllowedHeaders)
+	}
+}
+
+func TestCORSConfigInvalid(t *testing.T) {
+	l, _ := newLoader(t, baseConfig+"cors:\n  allowed_origins: [\"*\"]\n")
+
+	_, err := l.load()
+	assert.ErrorContains(t, err, "credentials can't be allowed to every origin")
+}
+
+func TestLeaguesConfig(t *testing.T) {
+	l, _ := newLoader(t, leaguesConfig(filepath.Join("..", "..", "configs", "leagues.example.json")))
+
+	cfg, err := l.load()
+	require.NoError(t, err)
+
+	reg, err := leagues(cfg)
+	require.NoError(t, err)
+
+	// The file replaces the G League, keeping the other defaults.
+	assert.Equal(t, "https://stats.gleague.nba.com", reg.Get(nba.GLeague).StatsBaseURL)
+	assert.Equal(t, "https://cdn.wnba.com", reg.Get(nba.WNBA).CDNBaseURL)
+	assert.Len(t, reg.All(), 4)
+
+	limits, err := upstreamLimits(cfg, reg)
+	require.NoError(t, err)
+	assert.Equal(t, gateway.HostLimit{PerSecond: 2, Burst: 5}, limits["stats.gleague.nba.com"])
+	assert.Equal(t, gateway.HostLimit{PerSecond: 10, Burst: 20}, limits["cdn.nba.com"])
+}
+
+func TestLeaguesConfigInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "leagues.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[{"id": "30", "name": "nba", "stats_base_url": "ftp://stats"}]`), 0o600))
+
+	l, _ := newLoader(t, leaguesConfig(path))
+
+	_, err := l.load()
+	assert.ErrorContains(t, err, `duplicate name "nba"`)
+	assert.ErrorContains(t, err, `nba stats_base_url "ftp://stats" is not an http(s) url`)
+	assert.ErrorContains(t, err, `nba needs a cdn_base_url`)
+	assert.ErrorContains(t, err, `unknown timezone ""`)
+}
+
+// leaguesConfig is baseConfig with a leagues file
+func leaguesConfig(path string) string {
+	return strings.Replace(baseConfig, "nba:\n", "nba:\n  leagues_file: "+path+"\n", 1)
+}
+
+func TestProvidersConfig(t *testing.T) {
+	var (
+		dir    = t.TempDir()
+		league = filepath.Join(dir, "leagues.json")
+	)
+
+	require.NoError(t, os.WriteFile(league, []byte(`[{"id": "90", "name": "ncaa", "stats_base_url": "https://stats.ncaa.test", "cdn_base_url": "https://cdn.ncaa.test", "timezone": "America/New_York"}]`), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "ncaa"), 0o755))
+
+	l, _ := newLoader(t, leaguesConfig(league)+"providers:\n  file_leagues: [ncaa]\n  files_dir: "+dir+"\n")
+
+	cfg, err := l.load()
+	require.NoError(t, err)
+
+	reg, err := leagues(cfg)
+	require.NoError(t, err)
+
+	opts, err := leagueProviders(cfg, reg)
+	require.NoError(t, err)
+	assert.Len(t, opts, 1)
+
+	limits, err := upstreamLimits(cfg, reg)
+	require.NoError(t, err)
+	assert.NotContains(t, limits, "")
+}
+
+func TestProvidersConfigInvalid(t *testing.T) {
+	t.Run("unknown league", func(t *testing.T) {
+		l, _ := newLoader(t, baseConfig+"providers:\n  file_leagues: [ncaa]\n  files_dir: "+t.TempDir()+"\n")
+
+		_, err := l.load()
+		assert.ErrorContains(t, err, `PROVIDERS_FILE_LEAGUES: unknown league "ncaa"`)
+	})
+
+	t.Run("missing dir", func(t *testing.T) {
+		l, _ := newLoader(t, baseConfig+"providers:\n  file_leagues: [nba]\n  files_dir: "+filepath.Join(t.TempDir(), "missing")+"\n")
+
+		_, err := l.load()
+		assert.ErrorContains(t, err, "PROVIDERS_FILES_DIR")
+	})
+}
@@ -10,37 +10,21 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/kelseyhightower/envconfig"
 	"github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats"
 	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway"
 	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/grpc"
 	"github.com/pedro-mealha/nba-stats-api/internal/app/http/rest"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/logging"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/metrics"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/ratelimit"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/storage"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/tracing"
 	"github.com/relistan/rubberneck"
 	"go.uber.org/zap"
 )
 
 type (
-	config struct {
-		Debug    bool
-		LogLevel string `split_words:"true"`
-		Web      struct {
-			APIHost         string        `split_words:"true" default:"0.0.0.0:8080"`
-			ReadTimeout     time.Duration `split_words:"true" default:"30s"`
-			WriteTimeout    time.Duration `split_words:"true" default:"2m"`
-			IdleTimeout     time.Duration `split_words:"true" default:"5s"`
-			ShutdownTimeout time.Duration `split_words:"true" default:"30s"`
-		}
-		NBA struct {
-			CDNBaseURL string        `split_words:"true" required:"true"`
-			BaseURL    string        `split_words:"true" required:"true"`
-			Timeout    time.Duration `default:"120s"`
-		}
-
-		WNBA struct {
-			CDNBaseURL string `split_words:"true" required:"true"`
-		}
-	}
-
 	// Notifier holds the context and channels to listen to the notifications
 	Notifier struct {
 		done chan struct{}
@@ -49,51 +33,155 @@ type (
 )
 
 func main() {
-	l, _ := zap.NewProduction()
+	loader := newConfigLoader(os.Getenv("CONFIG_FILE"))
+
+	cfg, err := loader.load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	l, level, err := logging.New(cfg.logging())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
 	defer l.Sync() //nolint: errcheck
 
+	// Code without a request scoped logger in its context falls back to it.
+	zap.ReplaceGlobals(l)
+
 	logger := l.Sugar()
 	ctx := context.Background()
 
-	if err := run(ctx, logger); err != nil {
+	if err := run(ctx, loader, cfg, logger, level); err != nil {
 		logger.Fatal(err)
 	}
 }
 
 // nolint
-func run(ctx context.Context, logger *zap.SugaredLogger) error {
+func run(ctx context.Context, loader *configLoader, cfg config, logger *zap.SugaredLogger, level zap.AtomicLevel) error {
 	defer logger.Info("completed")
 
 	// =========================================================================
 	// Configuration
 	// =========================================================================
-	logger.Info("Loading configs")
+	logger.Infow("Loaded configs", "file", loader.path, "log_level", level.String())
 
-	var cfg config
-	if err := envconfig.Process("", &cfg); err != nil {
-		return fmt.Errorf("failed to load the env vars: %w", err)
+	printer := rubberneck.NewPrinterWithKeyMasking(logger.Infof, maskSecrets, rubberneck.NoAddLineFeed)
+	printer.Print(cfg)
+
+	// =========================================================================
+	// Config tracing
+	// =========================================================================
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing.Exporter, cfg.Tracing.ServiceName)
+	if err != nil {
+		return fmt.Errorf("failed to setup tracing: %w", err)
 	}
 
-	printer := rubberneck.NewPrinter(logger.Infof, rubberneck.NoAddLineFeed)
-	printer.Print(cfg)
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Errorw("failed to flush traces", "err", err)
+		}
+	}()
 
 	// =========================================================================
 	// Config rest client
 	// =========================================================================
+	rt, err := gateway.NewTransport(cfg.NBA.Transport, cfg.NBA.RecordingsDir)
+	if err != nil {
+		return fmt.Errorf("failed to create nba transport: %w", err)
+	}
+
+	reg, err := leagues(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load leagues: %w", err)
+	}
+
+	providers, err := leagueProviders(cfg, reg)
+	if err != nil {
+		return err
+	}
+
+	if len(providers) > 0 {
+		logger.Infow("serving leagues from files", "leagues", cfg.Providers.FileLeagues, "dir", cfg.Providers.FilesDir)
+	}
+
+	limits, err := upstreamLimits(cfg, reg)
+	if err != nil {
+		return err
+	}
+
+	var (
+		nbaClient = gateway.NewClientWithTransport(cfg.NBA.Timeout, rt)
+		limiter   = gateway.NewLimiter(limits)
+		n         = nba.New(nbaClient, reg, nba.WithLimiter(limiter))
+	)
+
+	if err := metrics.RegisterLimiter(limiter); err != nil {
+		return fmt.Errorf("failed to register limiter metrics: %w", err)
+	}
+
+	// =========================================================================
+	// Config storage
+	// =========================================================================
 	var (
-		nbaClient = gateway.NewClientWithTimeout(cfg.NBA.Timeout)
-		n         = nba.New(nbaClient, cfg.NBA.BaseURL, cfg.NBA.CDNBaseURL, cfg.WNBA.CDNBaseURL)
+		st     storage.Store = storage.Noop{}
+		checks []rest.Option
 	)
 
+	// Replayed runs never reach the upstream, so there is nothing to check.
+	if cfg.NBA.Transport != gateway.ModeReplay {
+		checks = append(checks, rest.WithDegradedCheck("upstream", n.Ping))
+	}
+
+	if cfg.Storage.Path != "" {
+		logger.Infow("opening storage", "path", cfg.Storage.Path)
+
+		b, err := storage.Open(cfg.Storage.Path)
+		if err != nil {
+			return fmt.Errorf("failed to open storage: %w", err)
+		}
+
+		defer b.Close()
+
+		st = b
+		checks = append(checks, rest.WithCheck("storage", b.Check))
+	}
+
+	// =========================================================================
+	// Config rate limits
+	// =========================================================================
+	clientLimits, err := rateLimits(cfg)
+	if err != nil {
+		return err
+	}
+
+	rl, err := ratelimit.NewLimiter(clientLimits)
+	if err != nil {
+		return fmt.Errorf("invalid rate limits: %w", err)
+	}
+
+	logger.Infow("rate limiting clients", "api_keys", len(clientLimits.Keys), "tiers", len(clientLimits.Tiers))
+
 	// =========================================================================
 	// Start Server
 	// =========================================================================
 	logger.Info("initializing REST server")
 
 	var (
-		serverErrors = make(chan error, 1)
-		rs           = stats.NewService(n)
-		a            = rest.NewAPI(logger, rs)
+		serverErrors = make(chan error, 2)
+		rs           = stats.NewService(stats.NewNBAProvider(n, st), reg, providers...)
+		a            = rest.NewAPI(logger, rs, reg, append(checks,
+			rest.WithRateLimiter(rl),
+			rest.WithLogLevel(level, cfg.Admin.Token),
+			rest.WithCachePolicy(cachePolicy(cfg)),
+			rest.WithCORSPolicy(corsPolicy(cfg)),
+		)...)
 	)
 
 	server := &http.Server{
@@ -110,6 +198,32 @@ func run(ctx context.Context, logger *zap.SugaredLogger) error {
 		serverErrors <- server.ListenAndServe()
 	}()
 
+	// =========================================================================
+	// Start gRPC Server
+	// =========================================================================
+	logger.Info("initializing gRPC server")
+
+	lis, err := net.Listen("tcp", cfg.GRPC.Host)
+	if err != nil {
+		return fmt.Errorf("failed to listen for grpc: %w", err)
+	}
+
+	gs := grpc.NewServer(logger, rs, reg, grpc.WithRateLimiter(rl)).Register()
+
+	go func() {
+		logger.Infow("Initializing gRPC API", "host", cfg.GRPC.Host)
+		serverErrors <- gs.Serve(lis)
+	}()
+
+	// =========================================================================
+	// Reload config
+	// =========================================================================
+	reloadCtx, stopReload := context.WithCancel(ctx)
+	defer stopReload()
+
+	r := &reloader{logger: logger, loader: loader, cfg: cfg, level: level, api: a, rl: rl}
+	go r.watch(reloadCtx)
+
 	done := newSignal(ctx)
 
 	select {
@@ -118,10 +232,23 @@ func run(ctx context.Context, logger *zap.SugaredLogger) error {
 	case <-done.Done():
 		logger.Infow("start shutdown")
 
+		// Fail readiness first so the load balancer drains us.
+		a.Drain()
+
+		logger.Infow("draining", "delay", cfg.Web.DrainDelay)
+		time.Sleep(cfg.Web.DrainDelay)
+
 		// Give outstanding requests a deadline for completion.
 		ctx, cancel := context.WithTimeout(ctx, cfg.Web.ShutdownTimeout)
 		defer cancel()
 
+		grpcDone := make(chan struct{})
+
+		go func() {
+			gs.GracefulStop()
+			close(grpcDone)
+		}()
+
 		if err := server.Shutdown(ctx); err != nil {
 			logger.Errorw("failed to gracefully shutdown the server", "err", err)
 
@@ -129,6 +256,13 @@ func run(ctx context.Context, logger *zap.SugaredLogger) error {
 				return fmt.Errorf("could not stop server gracefully: %w", err)
 			}
 		}
+
+		// Streams like WatchGame can outlive the deadline, cut them.
+		select {
+		case <-grpcDone:
+		case <-ctx.Done():
+			gs.Stop()
+		}
 	}
 
 	return nil
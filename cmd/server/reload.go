@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"reflect"
+	"syscall"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/http/rest"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/ratelimit"
+	"go.uber.org/zap"
+)
+
+// reloader applies a new config to the running servers. Only the log level,
+// CORS policy, rate limits and cache TTLs change, the rest needs a restart.
+type reloader struct {
+	logger *zap.SugaredLogger
+	loader *configLoader
+	cfg    config
+
+	level zap.AtomicLevel
+	api   *rest.API
+	rl    *ratelimit.Limiter
+}
+
+// watch reloads the config on SIGHUP, and when the config file changes
+func (r *reloader) watch(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var (
+		tick    <-chan time.Time
+		modTime = r.modTime()
+	)
+
+	if r.loader.path != "" && r.cfg.ConfigPollInterval > 0 {
+		t := time.NewTicker(r.cfg.ConfigPollInterval)
+		defer t.Stop()
+
+		tick = t.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			r.reload("signal")
+		case <-tick:
+			if m := r.modTime(); !m.Equal(modTime) {
+				modTime = m
+				r.reload("file change")
+			}
+		}
+	}
+}
+
+func (r *reloader) modTime() time.Time {
+	if r.loader.path == "" {
+		return time.Time{}
+	}
+
+	fi, err := os.Stat(r.loader.path)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return fi.ModTime()
+}
+
+// reload keeps the current config when the new one is invalid
+func (r *reloader) reload(trigger string) {
+	logger := r.logger.With("trigger", trigger)
+
+	cfg, err := r.loader.load()
+	if err != nil {
+		logger.Errorw("failed to reload config, keeping the current one", "err", err)
+
+		return
+	}
+
+	limits, err := rateLimits(cfg)
+	if err == nil {
+		err = r.rl.Update(limits)
+	}
+
+	if err != nil {
+		logger.Errorw("failed to reload rate limits, keeping the current config", "err", err)
+
+		return
+	}
+
+	// A level changed at runtime is kept until the config changes it.
+	if cfg.LogLevel != r.cfg.LogLevel || cfg.Debug != r.cfg.Debug {
+		lvl, _ := cfg.logging().ParseLevel()
+		r.level.SetLevel(lvl)
+	}
+
+	r.api.SetCORSPolicy(corsPolicy(cfg))
+	r.api.SetCachePolicy(cachePolicy(cfg))
+
+	if !reflect.DeepEqual(cfg.restartOnly(), r.cfg.restartOnly()) {
+		logger.Warnw("config has changes that need a restart, they are ignored until then")
+	}
+
+	r.cfg = cfg
+
+	logger.Infow("reloaded config",
+		"log_level", r.level.String(),
+		"api_keys", len(limits.Keys),
+		"cors_origins", cfg.CORS.AllowedOrigins,
+	)
+}
@@ -0,0 +1,359 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/logging"
+)
+
+// boxscoreWorkers are the box scores fetched at a time for a comparison
+const boxscoreWorkers = 8
+
+var (
+	// ErrInvalidTeams is returned for unknown teams, or a team compared with itself
+	ErrInvalidTeams = errors.New("invalid teams")
+	// ErrSeasonUnavailable is returned for seasons the league schedule isn't of
+	ErrSeasonUnavailable = errors.New("season unavailable")
+)
+
+type (
+	CompareTeamsCommand struct {
+		LeagueID nba.LeagueID
+		// TeamA and TeamB are team ids or tricodes
+		TeamA string
+		TeamB string
+		// Season is like 2022-23 or 2022, the current one when empty
+		Season string
+	}
+
+	// Comparison is two teams side by side over a season. Differentials are
+	// team A less team B.
+	Comparison struct {
+		Season        string        `json:"season"`
+		TeamA         SeasonLine    `json:"team_a"`
+		TeamB         SeasonLine    `json:"team_b"`
+		HeadToHead    []Matchup     `json:"head_to_head"`
+		Differentials Differentials `json:"differentials"`
+		// MissingGames are finished games left out of the season lines, as
+		// their box scores couldn't be fetched
+		MissingGames []string `json:"missing_games,omitempty"`
+	}
+
+	// SeasonLine sums the finished games of a team, Opponent the lines of its
+	// opponents in them
+	SeasonLine struct {
+		ID                  int64       `json:"id"`
+		Name                string      `json:"name"`
+		Tricode             string      `json:"tricode"`
+		Games               int         `json:"games"`
+		Wins                int         `json:"wins"`
+		Losses              int         `json:"losses"`
+		Totals              Stats       `json:"totals"`
+		Opponent            Stats       `json:"opponent"`
+		FourFactors         FourFactors `json:"four_factors"`
+		OpponentFourFactors FourFactors `json:"opponent_four_factors"`
+	}
+
+	// Matchup is a game between the teams, with its differentials once over
+	Matchup struct {
+		GameID        string         `json:"game_id"`
+		Status        nba.GameStatus `json:"status"`
+		StartsAt      time.Time      `json:"starts_at"`
+		HomeTeamID    int64          `json:"home_team_id"`
+		TeamAScore    int64          `json:"team_a_score"`
+		TeamBScore    int64          `json:"team_b_score"`
+		Differentials *Differentials `json:"differentials,omitempty"`
+	}
+
+	// Differentials are per game, the shooting percentages of the totals
+	Differentials struct {
+		PT          float64     `json:"pts"`
+		RT          float64     `json:"reb"`
+		AST         float64     `json:"ast"`
+		STL         float64     `json:"stl"`
+		BLK         float64     `json:"blk"`
+		TO          float64     `json:"to"`
+		FGP         float64     `json:"fgp"`
+		ThreeFGP    float64     `json:"3fgp"`
+		FTP         float64     `json:"ftp"`
+		FourFactors FourFactors `json:"four_factors"`
+	}
+)
+
+// CompareTeams builds the season lines of the teams from the box scores of
+// their finished games in the schedule, read from p like any other. Games
+// whose box score fails are left out and listed as missing, unless every one
+// fails or the client ran out of tokens.
+func CompareTeams(ctx context.Context, p Provider, cmd CompareTeamsCommand) (Comparison, error) {
+	sc, err := p.GetSchedule(ctx, nba.GetScheduleCommand{LeagueID: cmd.LeagueID})
+	if err != nil {
+		return Comparison{}, err
+	}
+
+	if cmd.Season != "" && !sameSeason(cmd.Season, sc.Season) {
+		return Comparison{}, fmt.Errorf("%w: %q, the schedule is of %s", ErrSeasonUnavailable, cmd.Season, sc.Season)
+	}
+
+	a, ok := findTeam(sc, cmd.TeamA)
+	if !ok {
+		return Comparison{}, fmt.Errorf("%w: unknown team %q", ErrInvalidTeams, cmd.TeamA)
+	}
+
+	b, ok := findTeam(sc, cmd.TeamB)
+	if !ok {
+		return Comparison{}, fmt.Errorf("%w: unknown team %q", ErrInvalidTeams, cmd.TeamB)
+	}
+
+	if a.ID == b.ID {
+		return Comparison{}, fmt.Errorf("%w: %s can't be compared with itself", ErrInvalidTeams, a.Tricode)
+	}
+
+	var games []ScheduledGame
+
+	for _, g := range sc.Games {
+		if g.Status == nba.Final && (plays(g, a.ID) || plays(g, b.ID)) {
+			games = append(games, g)
+		}
+	}
+
+	// A season of box scores must not hold up one-off requests on the
+	// upstream limiter.
+	boxscores, err := getBoxscores(gateway.WithPriority(ctx, gateway.PriorityBackground), p, cmd.LeagueID, games)
+	if err != nil {
+		return Comparison{}, err
+	}
+
+	cmp := Comparison{
+		Season:     sc.Season,
+		TeamA:      newSeasonLine(a),
+		TeamB:      newSeasonLine(b),
+		HeadToHead: []Matchup{},
+	}
+
+	// In schedule order, so the sums of floats are always the same.
+	for _, g := range games {
+		bs, ok := boxscores[g.ID]
+		if !ok {
+			cmp.MissingGames = append(cmp.MissingGames, g.ID)
+
+			continue
+		}
+
+		cmp.TeamA.add(bs)
+		cmp.TeamB.add(bs)
+	}
+
+	cmp.Differentials = newDifferentials(cmp.TeamA, cmp.TeamB)
+
+	for _, g := range sc.Games {
+		if !plays(g, a.ID) || !plays(g, b.ID) {
+			continue
+		}
+
+		m := Matchup{
+			GameID:     g.ID,
+			Status:     g.Status,
+			StartsAt:   time.Time(g.StartsAt).UTC(),
+			HomeTeamID: g.HomeTeam.ID,
+			TeamAScore: g.HomeTeam.Score,
+			TeamBScore: g.AwayTeam.Score,
+		}
+
+		if g.HomeTeam.ID != a.ID {
+			m.TeamAScore, m.TeamBScore = m.TeamBScore, m.TeamAScore
+		}
+
+		if bs, ok := boxscores[g.ID]; ok {
+			la, lb := newSeasonLine(a), newSeasonLine(b)
+			la.add(bs)
+			lb.add(bs)
+
+			d := newDifferentials(la, lb)
+			m.TeamAScore, m.TeamBScore, m.Differentials = la.Totals.PT, lb.Totals.PT, &d
+		}
+
+		cmp.HeadToHead = append(cmp.HeadToHead, m)
+	}
+
+	return cmp, nil
+}
+
+// getBoxscores fetches the box scores of the games, boxscoreWorkers at a time.
+// Failed games are missing from the result, which only fails when all of them
+// do, or on the first rate limit or cancellation.
+func getBoxscores(ctx context.Context, p Provider, league nba.LeagueID, games []ScheduledGame) (map[string]Boxscore, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		fatalErr error
+		lastErr  error
+		sem      = make(chan struct{}, boxscoreWorkers)
+		res      = make(map[string]Boxscore, len(games))
+	)
+
+	for _, g := range games {
+		g := g
+
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+
+			bs, err := p.GetBoxscore(ctx, nba.GetBoxscoreCommand{GameID: g.ID, LeagueID: league})
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			switch {
+			case err == nil:
+				res[g.ID] = bs
+			case fatalErr != nil:
+			case errors.Is(err, ErrRateLimited) || ctx.Err() != nil:
+				fatalErr = err
+				cancel()
+			default:
+				logging.FromContext(ctx).Warnw("failed to get boxscore to compare", "err", err, "game_id", g.ID)
+
+				lastErr = err
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if fatalErr != nil {
+		return nil, fatalErr
+	}
+
+	if len(res) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+
+	return res, nil
+}
+
+// findTeam looks a team up in the schedule by id or tricode
+func findTeam(sc Schedule, team string) (TeamScore, bool) {
+	id, _ := strconv.ParseInt(team, 10, 64)
+
+	for _, g := range sc.Games {
+		for _, t := range []TeamScore{g.HomeTeam, g.AwayTeam} {
+			if (id != 0 && t.ID == id) || strings.EqualFold(t.Tricode, team) {
+				return TeamScore{ID: t.ID, Name: t.Name, Tricode: t.Tricode}, true
+			}
+		}
+	}
+
+	return TeamScore{}, false
+}
+
+func plays(g ScheduledGame, teamID int64) bool {
+	return g.HomeTeam.ID == teamID || g.AwayTeam.ID == teamID
+}
+
+// sameSeason compares seasons by the year they start in, 2022 being 2022-23
+func sameSeason(a, b string) bool {
+	return len(a) >= 4 && len(b) >= 4 && a[:4] == b[:4]
+}
+
+func newSeasonLine(t TeamScore) SeasonLine {
+	return SeasonLine{
+		ID:       t.ID,
+		Name:     t.Name,
+		Tricode:  t.Tricode,
+		Totals:   Stats{Minutes: zeroMins},
+		Opponent: Stats{Minutes: zeroMins},
+	}
+}
+
+// add sums the game of the box score to the line, when the team played it
+func (l *SeasonLine) add(b Boxscore) {
+	team, opp := b.HomeTeam, b.AwayTeam
+
+	switch l.ID {
+	case b.HomeTeam.ID:
+	case b.AwayTeam.ID:
+		team, opp = b.AwayTeam, b.HomeTeam
+	default:
+		return
+	}
+
+	l.Games++
+
+	if team.Stats.PT > opp.Stats.PT {
+		l.Wins++
+	} else {
+		l.Losses++
+	}
+
+	l.Totals = sumStats(l.Totals, team.Stats)
+	l.Opponent = sumStats(l.Opponent, opp.Stats)
+	l.FourFactors = NewFourFactors(l.Totals, l.Opponent)
+	l.OpponentFourFactors = NewFourFactors(l.Opponent, l.Totals)
+}
+
+// sumStats adds up the counting stats and works the percentages and minutes
+// out of the sums
+func sumStats(a, b Stats) Stats {
+	s := Stats{
+		SecondsPlayed: a.SecondsPlayed + b.SecondsPlayed,
+		FGM:           a.FGM + b.FGM,
+		FGA:           a.FGA + b.FGA,
+		ThreeFGM:      a.ThreeFGM + b.ThreeFGM,
+		ThreeFGA:      a.ThreeFGA + b.ThreeFGA,
+		FTM:           a.FTM + b.FTM,
+		FTA:           a.FTA + b.FTA,
+		RO:            a.RO + b.RO,
+		RD:            a.RD + b.RD,
+		RT:            a.RT + b.RT,
+		RTeam:         a.RTeam + b.RTeam,
+		AST:           a.AST + b.AST,
+		STL:           a.STL + b.STL,
+		BLK:           a.BLK + b.BLK,
+		TO:            a.TO + b.TO,
+		TOT:           a.TOT + b.TOT,
+		FP:            a.FP + b.FP,
+		FD:            a.FD + b.FD,
+		PT:            a.PT + b.PT,
+		PlusMinus:     a.PlusMinus + b.PlusMinus,
+	}
+
+	s.Minutes = fmt.Sprintf("%d:%02d", int(s.SecondsPlayed)/60, int(s.SecondsPlayed)%60)
+	s.FGP = percentage(float64(s.FGM), float64(s.FGA))
+	s.ThreeFGP = percentage(float64(s.ThreeFGM), float64(s.ThreeFGA))
+	s.FTP = percentage(float64(s.FTM), float64(s.FTA))
+
+	return s
+}
+
+func newDifferentials(a, b SeasonLine) Differentials {
+	perGame := func(n int64, games int) float64 { return ratio(float64(n), float64(games)) }
+	diff := func(stat func(Stats) int64) float64 {
+		return Round(perGame(stat(a.Totals), a.Games)-perGame(stat(b.Totals), b.Games), 1)
+	}
+
+	return Differentials{
+		PT:          diff(func(s Stats) int64 { return s.PT }),
+		RT:          diff(func(s Stats) int64 { return s.RT }),
+		AST:         diff(func(s Stats) int64 { return s.AST }),
+		STL:         diff(func(s Stats) int64 { return s.STL }),
+		BLK:         diff(func(s Stats) int64 { return s.BLK }),
+		TO:          diff(func(s Stats) int64 { return s.TO }),
+		FGP:         Round(a.Totals.FGP-b.Totals.FGP, 1),
+		ThreeFGP:    Round(a.Totals.ThreeFGP-b.Totals.ThreeFGP, 1),
+		FTP:         Round(a.Totals.FTP-b.Totals.FTP, 1),
+		FourFactors: a.FourFactors.minus(b.FourFactors),
+	}
+}
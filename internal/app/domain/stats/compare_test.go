@@ -0,0 +1,200 @@
+package stats_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	bos = stats.TeamScore{ID: 1610612738, Name: "Celtics", Tricode: "BOS"}
+	phi = stats.TeamScore{ID: 1610612755, Name: "76ers", Tricode: "PHI"}
+	nyk = stats.TeamScore{ID: 1610612752, Name: "Knicks", Tricode: "NYK"}
+)
+
+func TestFourFactors(t *testing.T) {
+	t.Parallel()
+
+	var (
+		team = stats.Stats{FGM: 40, FGA: 80, ThreeFGM: 10, FTM: 20, FTA: 25, RO: 10, TO: 14}
+		opp  = stats.Stats{RD: 30}
+	)
+
+	assert.Equal(t, stats.FourFactors{EFGP: 56.3, TOVP: 13.3, ORBP: 25, FTRate: 0.25}, stats.NewFourFactors(team, opp))
+	assert.Equal(t, stats.FourFactors{}, stats.NewFourFactors(stats.Stats{}, stats.Stats{}))
+}
+
+func TestCompareTeams(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx = context.Background()
+		p   = new(stats.ProviderMock)
+		day = time.Date(2022, 10, 18, 23, 30, 0, 0, time.UTC)
+	)
+
+	sc := stats.Schedule{Season: "2022-23", Games: []stats.ScheduledGame{
+		game("0022200001", nba.Final, day, bos, 126, phi, 117),
+		game("0022200010", nba.Final, day.AddDate(0, 0, 2), nyk, 100, bos, 90),
+		game("0022200020", nba.Final, day.AddDate(0, 0, 4), phi, 105, nyk, 95),
+		game("0022200030", nba.Scheduled, day.AddDate(0, 0, 30), phi, 0, bos, 0),
+	}}
+
+	p.On("GetSchedule", mock.Anything, nba.GetScheduleCommand{LeagueID: nba.NBA}).Return(sc, nil)
+
+	for _, g := range sc.Games[:3] {
+		p.On("GetBoxscore", mock.Anything, nba.GetBoxscoreCommand{GameID: g.ID, LeagueID: nba.NBA}).Return(boxscore(g), nil)
+	}
+
+	cmp, err := stats.CompareTeams(ctx, p, stats.CompareTeamsCommand{LeagueID: nba.NBA, TeamA: "bos", TeamB: "1610612755", Season: "2022"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "2022-23", cmp.Season)
+
+	assert.Equal(t, "BOS", cmp.TeamA.Tricode)
+	assert.Equal(t, 2, cmp.TeamA.Games)
+	assert.Equal(t, 1, cmp.TeamA.Wins)
+	assert.Equal(t, 1, cmp.TeamA.Losses)
+	assert.Equal(t, int64(216), cmp.TeamA.Totals.PT)
+	assert.Equal(t, int64(217), cmp.TeamA.Opponent.PT)
+	assert.Equal(t, "480:00", cmp.TeamA.Totals.Minutes)
+	assert.Equal(t, 50.0, cmp.TeamA.Totals.FGP)
+
+	assert.Equal(t, "PHI", cmp.TeamB.Tricode)
+	assert.Equal(t, 2, cmp.TeamB.Games)
+	assert.Equal(t, 1, cmp.TeamB.Wins)
+	assert.Equal(t, int64(222), cmp.TeamB.Totals.PT)
+
+	// 108 less 111 points a game.
+	assert.Equal(t, -3.0, cmp.Differentials.PT)
+
+	require.Len(t, cmp.HeadToHead, 2)
+
+	final := cmp.HeadToHead[0]
+	assert.Equal(t, "0022200001", final.GameID)
+	assert.Equal(t, bos.ID, final.HomeTeamID)
+	assert.Equal(t, int64(126), final.TeamAScore)
+	assert.Equal(t, int64(117), final.TeamBScore)
+	require.NotNil(t, final.Differentials)
+	assert.Equal(t, 9.0, final.Differentials.PT)
+
+	scheduled := cmp.HeadToHead[1]
+	assert.Equal(t, nba.Scheduled, scheduled.Status)
+	assert.Equal(t, phi.ID, scheduled.HomeTeamID)
+	assert.Equal(t, day.AddDate(0, 0, 30), scheduled.StartsAt)
+	assert.Nil(t, scheduled.Differentials)
+}
+
+func TestCompareTeamsMissingGames(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx = context.Background()
+		day = time.Date(2022, 10, 18, 23, 30, 0, 0, time.UTC)
+		sc  = stats.Schedule{Season: "2022-23", Games: []stats.ScheduledGame{
+			game("0022200001", nba.Final, day, bos, 126, phi, 117),
+			game("0022200010", nba.Final, day.AddDate(0, 0, 2), nyk, 100, bos, 90),
+		}}
+		background = mock.MatchedBy(func(ctx context.Context) bool {
+			return gateway.PriorityFromContext(ctx) == gateway.PriorityBackground
+		})
+	)
+
+	tests := []struct {
+		scenario string
+
+		err error
+
+		expMissing []string
+		expErr     error
+	}{
+		{scenario: "failed to get boxscore", err: errFailed, expMissing: []string{"0022200010"}},
+		{scenario: "rate limited", err: stats.ErrRateLimited, expErr: stats.ErrRateLimited},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			p := new(stats.ProviderMock)
+			p.On("GetSchedule", mock.Anything, mock.Anything).Return(sc, nil)
+			p.On("GetBoxscore", background, nba.GetBoxscoreCommand{GameID: "0022200001"}).Return(boxscore(sc.Games[0]), nil)
+			p.On("GetBoxscore", background, nba.GetBoxscoreCommand{GameID: "0022200010"}).Return(stats.Boxscore{}, tt.err)
+
+			cmp, err := stats.CompareTeams(ctx, p, stats.CompareTeamsCommand{TeamA: "BOS", TeamB: "PHI"})
+			if tt.expErr != nil {
+				assert.ErrorIs(t, err, tt.expErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expMissing, cmp.MissingGames)
+			assert.Equal(t, 1, cmp.TeamA.Games)
+			assert.Equal(t, 1, cmp.TeamB.Games)
+		})
+	}
+}
+
+func TestCompareTeamsInvalid(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx = context.Background()
+		p   = new(stats.ProviderMock)
+		sc  = stats.Schedule{Season: "2022-23", Games: []stats.ScheduledGame{
+			game("0022200001", nba.Final, time.Now(), bos, 126, phi, 117),
+		}}
+	)
+
+	p.On("GetSchedule", mock.Anything, mock.Anything).Return(sc, nil)
+	p.On("GetBoxscore", mock.Anything, mock.Anything).Return(stats.Boxscore{}, errFailed)
+
+	tests := []struct {
+		scenario string
+
+		cmd stats.CompareTeamsCommand
+
+		expErr error
+	}{
+		{scenario: "unknown team", cmd: stats.CompareTeamsCommand{TeamA: "BOS", TeamB: "NYK"}, expErr: stats.ErrInvalidTeams},
+		{scenario: "same team", cmd: stats.CompareTeamsCommand{TeamA: "BOS", TeamB: "1610612738"}, expErr: stats.ErrInvalidTeams},
+		{scenario: "past season", cmd: stats.CompareTeamsCommand{TeamA: "BOS", TeamB: "PHI", Season: "2021-22"}, expErr: stats.ErrSeasonUnavailable},
+		{scenario: "failed to get every boxscore", cmd: stats.CompareTeamsCommand{TeamA: "BOS", TeamB: "PHI"}, expErr: errFailed},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := stats.CompareTeams(ctx, p, tt.cmd)
+			assert.ErrorIs(t, err, tt.expErr)
+		})
+	}
+}
+
+func game(id string, status nba.GameStatus, at time.Time, home stats.TeamScore, homeScore int64, away stats.TeamScore, awayScore int64) stats.ScheduledGame {
+	home.Score, away.Score = homeScore, awayScore
+
+	return stats.ScheduledGame{ID: id, Status: status, StartsAt: nba.GameTime(at), HomeTeam: home, AwayTeam: away}
+}
+
+// boxscore is a game of regulation, every team shooting 50%
+func boxscore(g stats.ScheduledGame) stats.Boxscore {
+	team := func(t stats.TeamScore) stats.Team {
+		return stats.Team{ID: t.ID, Tricode: t.Tricode, Stats: stats.Stats{SecondsPlayed: 14400, FGM: 40, FGA: 80, PT: t.Score}}
+	}
+
+	return stats.Boxscore{GameID: g.ID, Status: g.Status, HomeTeam: team(g.HomeTeam), AwayTeam: team(g.AwayTeam)}
+}
@@ -2,17 +2,18 @@ package stats
 
 import (
 	"fmt"
+	"math"
 	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
 )
 
-const (
-	// Matches PT25M12.02S or PT04M05.00S
-	minutesRegex = `(?:PT)([1-9]{1}|(?:0))(\d{1})(?:M)(\d{2})(?:\.\d{2}S)`
+const zeroMins = "0:00"
 
-	zeroMins = "0:00"
-)
+// minutesRegex matches PT25M12.02S, PT04M05.00S or team totals like PT240M
+var minutesRegex = regexp.MustCompile(`^PT(\d+)M(?:(\d+(?:\.\d+)?)S)?$`)
 
 type (
 	Scoreboard struct {
@@ -25,12 +26,41 @@ type (
 		StartsAt nba.GameTime `json:"starts_at"`
 		HomeTeam Team         `json:"home_team"`
 		AwayTeam Team         `json:"away_team"`
+
+		// Status isn't part of the v1 schema. It is served by v2 and sets the
+		// Cache-Control of the responses.
+		Status nba.GameStatus `json:"-"`
 	}
 
 	Boxscore struct {
 		GameID   string `json:"game_id"`
 		HomeTeam Team   `json:"home_team"`
 		AwayTeam Team   `json:"away_team"`
+
+		// Status is left out of v1 like the one of Game
+		Status nba.GameStatus `json:"-"`
+	}
+
+	// Schedule is the season of a league, from its first game to the last,
+	// preseason and exhibitions left out
+	Schedule struct {
+		Season string          `json:"season"`
+		Games  []ScheduledGame `json:"games"`
+	}
+
+	ScheduledGame struct {
+		ID       string         `json:"id"`
+		Status   nba.GameStatus `json:"status"`
+		StartsAt nba.GameTime   `json:"starts_at"`
+		HomeTeam TeamScore      `json:"home_team"`
+		AwayTeam TeamScore      `json:"away_team"`
+	}
+
+	TeamScore struct {
+		ID      int64  `json:"id"`
+		Name    string `json:"name"`
+		Tricode string `json:"tricode"`
+		Score   int64  `json:"score"`
 	}
 
 	Team struct {
@@ -49,29 +79,32 @@ type (
 	}
 
 	Stats struct {
-		Minutes   string  `json:"min"`
-		FGM       int64   `json:"fgm"`
-		FGA       int64   `json:"fga"`
-		FGP       float64 `json:"fgp"`
-		ThreeFGM  int64   `json:"3fgm"`
-		ThreeFGA  int64   `json:"3fga"`
-		ThreeFGP  float64 `json:"3fgp"`
-		FTM       int64   `json:"ftm"`
-		FTA       int64   `json:"fta"`
-		FTP       float64 `json:"ftp"`
-		RO        int64   `json:"oreb"`
-		RD        int64   `json:"dreb"`
-		RT        int64   `json:"reb"`
-		RTeam     int64   `json:"rebt"`
-		AST       int64   `json:"ast"`
-		STL       int64   `json:"stl"`
-		BLK       int64   `json:"blk"`
-		TO        int64   `json:"to"`
-		TOT       int64   `json:"tot"`
-		FP        int64   `json:"pf"`
-		FD        int64   `json:"fd"`
-		PT        int64   `json:"pts"`
-		PlusMinus float64 `json:"plus_minus"`
+		Minutes string `json:"min"`
+		// SecondsPlayed is Minutes as a number, with the fractions of a second.
+		// It is left out of the unversioned schema.
+		SecondsPlayed float64 `json:"-"`
+		FGM           int64   `json:"fgm"`
+		FGA           int64   `json:"fga"`
+		FGP           float64 `json:"fgp"`
+		ThreeFGM      int64   `json:"3fgm"`
+		ThreeFGA      int64   `json:"3fga"`
+		ThreeFGP      float64 `json:"3fgp"`
+		FTM           int64   `json:"ftm"`
+		FTA           int64   `json:"fta"`
+		FTP           float64 `json:"ftp"`
+		RO            int64   `json:"oreb"`
+		RD            int64   `json:"dreb"`
+		RT            int64   `json:"reb"`
+		RTeam         int64   `json:"rebt"`
+		AST           int64   `json:"ast"`
+		STL           int64   `json:"stl"`
+		BLK           int64   `json:"blk"`
+		TO            int64   `json:"to"`
+		TOT           int64   `json:"tot"`
+		FP            int64   `json:"pf"`
+		FD            int64   `json:"fd"`
+		PT            int64   `json:"pts"`
+		PlusMinus     float64 `json:"plus_minus"`
 	}
 )
 
@@ -85,6 +118,7 @@ func NewScoreboard(sb nba.ScoreboardData) Scoreboard {
 func NewBoxscore(bs nba.BoxscoreData) Boxscore {
 	b := Boxscore{
 		GameID: bs.Boxscore.ID,
+		Status: bs.Boxscore.Status,
 		HomeTeam: Team{
 			ID:      bs.Boxscore.HomeTeam.ID,
 			Name:    bs.Boxscore.HomeTeam.Name,
@@ -104,12 +138,37 @@ func NewBoxscore(bs nba.BoxscoreData) Boxscore {
 	return b
 }
 
+// NewSchedule keeps the regular season, play-in and playoff games, whose ids
+// have a 2, 5 or 4 as third digit
+func NewSchedule(sd nba.ScheduleData) Schedule {
+	sc := Schedule{Season: sd.Schedule.SeasonYear, Games: []ScheduledGame{}}
+
+	for _, d := range sd.Schedule.GameDates {
+		for _, g := range d.Games {
+			if len(g.ID) < 3 || !strings.ContainsRune("245", rune(g.ID[2])) {
+				continue
+			}
+
+			sc.Games = append(sc.Games, ScheduledGame{
+				ID:       g.ID,
+				Status:   g.Status,
+				StartsAt: g.StartsAt,
+				HomeTeam: TeamScore(g.HomeTeam),
+				AwayTeam: TeamScore(g.AwayTeam),
+			})
+		}
+	}
+
+	return sc
+}
+
 func addGames(gs []nba.Game) []Game {
 	gg := make([]Game, len(gs))
 
 	for i, g := range gs {
 		gg[i] = Game{
 			ID:       g.ID,
+			Status:   g.Status,
 			StartsAt: g.StartsAt,
 			HomeTeam: Team{
 				ID:      g.HomeTeam.ID,
@@ -142,17 +201,26 @@ func addPlayers(ps []nba.Player) []Player {
 	return pp
 }
 
-func parseMinutes(min string) string {
-	re := regexp.MustCompile(minutesRegex)
-	if match := re.FindStringSubmatch(min); len(match) > 0 {
-		if match[1] == "0" {
-			match[1] = ""
-		}
+// parseMinutes turns PT25M12.02S into the display string 25:12 and the
+// seconds played, 1512.02
+func parseMinutes(min string) (string, float64) {
+	match := minutesRegex.FindStringSubmatch(min)
+	if match == nil {
+		return zeroMins, 0
+	}
+
+	m, err := strconv.Atoi(match[1])
+	if err != nil {
+		return zeroMins, 0
+	}
 
-		return fmt.Sprintf("%s%s:%s", match[1], match[2], match[3])
+	var sec float64
+	if match[2] != "" {
+		sec, _ = strconv.ParseFloat(match[2], 64)
 	}
 
-	return zeroMins
+	// Upstream has hundredths of a second, keep them exact
+	return fmt.Sprintf("%d:%02d", m, int(sec)), math.Round((float64(m*60)+sec)*100) / 100
 }
 
 func parsePercentages(p float64) float64 {
@@ -160,13 +228,32 @@ func parsePercentages(p float64) float64 {
 }
 
 func statsDecorator(s nba.Stats) Stats {
-	sts := Stats(s)
-
-	sts.FGP = parsePercentages(sts.FGP)
-	sts.FTP = parsePercentages(sts.FTP)
-	sts.ThreeFGP = parsePercentages(sts.ThreeFGP)
+	sts := Stats{
+		FGM:       s.FGM,
+		FGA:       s.FGA,
+		FGP:       parsePercentages(s.FGP),
+		ThreeFGM:  s.ThreeFGM,
+		ThreeFGA:  s.ThreeFGA,
+		ThreeFGP:  parsePercentages(s.ThreeFGP),
+		FTM:       s.FTM,
+		FTA:       s.FTA,
+		FTP:       parsePercentages(s.FTP),
+		RO:        s.RO,
+		RD:        s.RD,
+		RT:        s.RT,
+		RTeam:     s.RTeam,
+		AST:       s.AST,
+		STL:       s.STL,
+		BLK:       s.BLK,
+		TO:        s.TO,
+		TOT:       s.TOT,
+		FP:        s.FP,
+		FD:        s.FD,
+		PT:        s.PT,
+		PlusMinus: s.PlusMinus,
+	}
 
-	sts.Minutes = parseMinutes(sts.Minutes)
+	sts.Minutes, sts.SecondsPlayed = parseMinutes(s.Minutes)
 
 	return sts
 }
@@ -0,0 +1,54 @@
+package stats
+
+import "math"
+
+// FourFactors are Dean Oliver's four factors of a stat line, against the line
+// of its opponent. Percentages are 0 to 100, like the shooting ones.
+type FourFactors struct {
+	// EFGP is the field goal percentage with threes worth one and a half
+	EFGP float64 `json:"efgp"`
+	// TOVP is the turnovers per 100 plays
+	TOVP float64 `json:"tovp"`
+	// ORBP is the share of the offensive rebounds available grabbed
+	ORBP float64 `json:"orbp"`
+	// FTRate is the free throws made per field goal attempted
+	FTRate float64 `json:"ft_rate"`
+}
+
+func NewFourFactors(team, opp Stats) FourFactors {
+	return FourFactors{
+		EFGP:   Round(percentage(float64(team.FGM)+0.5*float64(team.ThreeFGM), float64(team.FGA)), 1),
+		TOVP:   Round(percentage(float64(team.TO), float64(team.FGA)+0.44*float64(team.FTA)+float64(team.TO)), 1),
+		ORBP:   Round(percentage(float64(team.RO), float64(team.RO+opp.RD)), 1),
+		FTRate: Round(ratio(float64(team.FTM), float64(team.FGA)), 3),
+	}
+}
+
+// minus is f less o, factor by factor
+func (f FourFactors) minus(o FourFactors) FourFactors {
+	return FourFactors{
+		EFGP:   Round(f.EFGP-o.EFGP, 1),
+		TOVP:   Round(f.TOVP-o.TOVP, 1),
+		ORBP:   Round(f.ORBP-o.ORBP, 1),
+		FTRate: Round(f.FTRate-o.FTRate, 3),
+	}
+}
+
+func percentage(n, d float64) float64 { return ratio(n, d) * 100 }
+
+// ratio is zero when there is nothing to divide by, like a team without
+// attempts
+func ratio(n, d float64) float64 {
+	if d == 0 {
+		return 0
+	}
+
+	return n / d
+}
+
+// Round keeps the given decimals of a stat, 45.300000000000004 to 45.3
+func Round(f float64, decimals int) float64 {
+	p := math.Pow(10, float64(decimals))
+
+	return math.Round(f*p) / p
+}
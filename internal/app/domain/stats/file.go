@@ -0,0 +1,190 @@
+package stats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+)
+
+// clockRegex matches the minutes of the unversioned responses, like 25:12
+var clockRegex = regexp.MustCompile(`^(\d+):([0-5]\d)$`)
+
+type (
+	// FileProvider serves games from JSON files, for leagues without an API
+	// and for tests. The files are in a directory per league name:
+	//
+	//	ncaa/scoreboard_2024-03-21.json
+	//	ncaa/boxscore_9022300101.json
+	//	ncaa/schedule.json
+	//
+	// They have the shape of the unversioned responses, but starts_at is in
+	// RFC 3339. A day without a scoreboard file has no games. The schedule is
+	// {"season": "2023-24", "games": [...]}, its teams with their score.
+	FileProvider struct {
+		fsys    fs.FS
+		leagues *nba.Registry
+	}
+
+	// fileScoreboard is dated by its file name
+	fileScoreboard struct {
+		Games []fileGame `json:"games"`
+	}
+
+	fileGame struct {
+		ID       string         `json:"id"`
+		Status   nba.GameStatus `json:"status"`
+		StartsAt time.Time      `json:"starts_at"`
+		HomeTeam Team           `json:"home_team"`
+		AwayTeam Team           `json:"away_team"`
+	}
+
+	fileBoxscore struct {
+		GameID   string         `json:"game_id"`
+		Status   nba.GameStatus `json:"status"`
+		HomeTeam Team           `json:"home_team"`
+		AwayTeam Team           `json:"away_team"`
+	}
+
+	fileSchedule struct {
+		Season string              `json:"season"`
+		Games  []fileScheduledGame `json:"games"`
+	}
+
+	fileScheduledGame struct {
+		ID       string         `json:"id"`
+		Status   nba.GameStatus `json:"status"`
+		StartsAt time.Time      `json:"starts_at"`
+		HomeTeam TeamScore      `json:"home_team"`
+		AwayTeam TeamScore      `json:"away_team"`
+	}
+)
+
+// NewFileProvider serves the leagues of the registry from the files of fsys
+func NewFileProvider(fsys fs.FS, leagues *nba.Registry) *FileProvider {
+	return &FileProvider{fsys: fsys, leagues: leagues}
+}
+
+// GetScoreboard reads the scoreboard of the day, today in the league timezone
+// without a date
+func (p *FileProvider) GetScoreboard(_ context.Context, cmd nba.GetScoreboardCommand) (Scoreboard, error) {
+	date := cmd.Date
+	if date == "" {
+		date = time.Now().In(p.leagues.Get(cmd.LeagueID).Location()).Format(dateFormat)
+	}
+
+	day, err := time.Parse(dateFormat, date)
+	if err != nil {
+		return Scoreboard{}, fmt.Errorf("%w %q: %w", ErrInvalidDate, date, err)
+	}
+
+	var f fileScoreboard
+
+	err = p.read(p.leagues.Get(cmd.LeagueID).Name+"/scoreboard_"+date+".json", &f)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Scoreboard{Date: nba.GameDate(day), Games: []Game{}}, nil
+	}
+
+	if err != nil {
+		return Scoreboard{}, err
+	}
+
+	sb := Scoreboard{Date: nba.GameDate(day), Games: make([]Game, len(f.Games))}
+
+	for i, g := range f.Games {
+		sb.Games[i] = Game{
+			ID:       g.ID,
+			Status:   g.Status,
+			StartsAt: nba.GameTime(g.StartsAt.UTC()),
+			HomeTeam: fileTeam(g.HomeTeam),
+			AwayTeam: fileTeam(g.AwayTeam),
+		}
+	}
+
+	return sb, nil
+}
+
+func (p *FileProvider) GetBoxscore(_ context.Context, cmd nba.GetBoxscoreCommand) (Boxscore, error) {
+	var f fileBoxscore
+
+	if err := p.read(p.leagues.Get(cmd.LeagueID).Name+"/boxscore_"+cmd.GameID+".json", &f); err != nil {
+		return Boxscore{}, err
+	}
+
+	return Boxscore{
+		GameID:   f.GameID,
+		Status:   f.Status,
+		HomeTeam: fileTeam(f.HomeTeam),
+		AwayTeam: fileTeam(f.AwayTeam),
+	}, nil
+}
+
+func (p *FileProvider) GetSchedule(_ context.Context, cmd nba.GetScheduleCommand) (Schedule, error) {
+	var f fileSchedule
+
+	if err := p.read(p.leagues.Get(cmd.LeagueID).Name+"/schedule.json", &f); err != nil {
+		return Schedule{}, err
+	}
+
+	sc := Schedule{Season: f.Season, Games: make([]ScheduledGame, len(f.Games))}
+
+	for i, g := range f.Games {
+		sc.Games[i] = ScheduledGame{
+			ID:       g.ID,
+			Status:   g.Status,
+			StartsAt: nba.GameTime(g.StartsAt.UTC()),
+			HomeTeam: g.HomeTeam,
+			AwayTeam: g.AwayTeam,
+		}
+	}
+
+	return sc, nil
+}
+
+// read decodes a file, refusing names that would leave the directory. Names
+// are not cleaned first, so a game id like ../x is refused.
+func (p *FileProvider) read(name string, v any) error {
+	if !fs.ValidPath(name) {
+		return fmt.Errorf("invalid file name %q", name)
+	}
+
+	data, err := fs.ReadFile(p.fsys, name)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// fileTeam fills in the seconds played, which the files only have as minutes
+func fileTeam(t Team) Team {
+	t.Stats.SecondsPlayed = clockSeconds(t.Stats.Minutes)
+
+	for i := range t.Players {
+		t.Players[i].Stats.SecondsPlayed = clockSeconds(t.Players[i].Stats.Minutes)
+	}
+
+	return t
+}
+
+func clockSeconds(min string) float64 {
+	m := clockRegex.FindStringSubmatch(min)
+	if m == nil {
+		return 0
+	}
+
+	mins, _ := strconv.ParseFloat(m[1], 64)
+	secs, _ := strconv.ParseFloat(m[2], 64)
+
+	return mins*60 + secs
+}
@@ -0,0 +1,72 @@
+package stats_test
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileProvider(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx = context.Background()
+		p   = stats.NewFileProvider(fstest.MapFS{
+			"gleague/scoreboard_2022-11-04.json": {Data: []byte(`{"games": [{"id": "2022200001", "status": "scheduled", "starts_at": "2022-11-04T19:00:00-04:00",
+				"home_team": {"id": 1, "tricode": "MNE"}, "away_team": {"id": 2, "tricode": "WCK"}}]}`)},
+			"gleague/boxscore_2022200001.json": {Data: []byte(`{"game_id": "2022200001", "status": "final",
+				"home_team": {"id": 1, "stats": {"min": "240:00", "pts": 110}, "players": [{"last_name": "Doe", "stats": {"min": "25:12"}}]},
+				"away_team": {"id": 2, "stats": {"min": "265:00"}}}`)},
+			"gleague/boxscore_2022200002.json": {Data: []byte(`{"game_id": "2022200002", "status": "postponed"}`)},
+			"gleague/schedule.json": {Data: []byte(`{"season": "2022-23", "games": [{"id": "2022200001", "status": "final", "starts_at": "2022-11-04T23:00:00Z",
+				"home_team": {"id": 1, "tricode": "MNE", "score": 110}, "away_team": {"id": 2, "tricode": "WCK", "score": 98}}]}`)},
+		}, leagues)
+	)
+
+	sb, err := p.GetScoreboard(ctx, nba.GetScoreboardCommand{Date: "2022-11-04", LeagueID: nba.GLeague})
+	require.NoError(t, err)
+	require.Len(t, sb.Games, 1)
+	assert.Equal(t, nba.GameDate(time.Date(2022, 11, 4, 0, 0, 0, 0, time.UTC)), sb.Date)
+	assert.Equal(t, nba.Scheduled, sb.Games[0].Status)
+	assert.Equal(t, nba.GameTime(time.Date(2022, 11, 4, 23, 0, 0, 0, time.UTC)), sb.Games[0].StartsAt)
+	assert.Equal(t, "WCK", sb.Games[0].AwayTeam.Tricode)
+
+	// A day without a file has no games.
+	sb, err = p.GetScoreboard(ctx, nba.GetScoreboardCommand{Date: "2022-11-05", LeagueID: nba.GLeague})
+	require.NoError(t, err)
+	assert.Empty(t, sb.Games)
+	assert.NotNil(t, sb.Games)
+
+	_, err = p.GetScoreboard(ctx, nba.GetScoreboardCommand{Date: "11/05/2022", LeagueID: nba.GLeague})
+	assert.ErrorIs(t, err, stats.ErrInvalidDate)
+
+	bs, err := p.GetBoxscore(ctx, nba.GetBoxscoreCommand{GameID: "2022200001", LeagueID: nba.GLeague})
+	require.NoError(t, err)
+	assert.Equal(t, nba.Final, bs.Status)
+	assert.Equal(t, int64(110), bs.HomeTeam.Stats.PT)
+	assert.Equal(t, 14400.0, bs.HomeTeam.Stats.SecondsPlayed)
+	assert.Equal(t, 1512.0, bs.HomeTeam.Players[0].Stats.SecondsPlayed)
+	assert.Equal(t, 15900.0, bs.AwayTeam.Stats.SecondsPlayed)
+
+	sc, err := p.GetSchedule(ctx, nba.GetScheduleCommand{LeagueID: nba.GLeague})
+	require.NoError(t, err)
+	assert.Equal(t, "2022-23", sc.Season)
+	require.Len(t, sc.Games, 1)
+	assert.Equal(t, stats.TeamScore{ID: 1, Tricode: "MNE", Score: 110}, sc.Games[0].HomeTeam)
+
+	_, err = p.GetBoxscore(ctx, nba.GetBoxscoreCommand{GameID: "2022200003", LeagueID: nba.GLeague})
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	_, err = p.GetBoxscore(ctx, nba.GetBoxscoreCommand{GameID: "2022200002", LeagueID: nba.GLeague})
+	assert.ErrorContains(t, err, `unknown game status "postponed"`)
+
+	_, err = p.GetBoxscore(ctx, nba.GetBoxscoreCommand{GameID: "../../secrets", LeagueID: nba.GLeague})
+	assert.ErrorContains(t, err, "invalid file name")
+}
@@ -0,0 +1,81 @@
+package stats_test
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/storage"
+	"github.com/stretchr/testify/suite"
+)
+
+// update rewrites the golden files with the current output:
+//
+//	go test ./internal/app/domain/stats/ -update
+var update = flag.Bool("update", false, "update golden files")
+
+type GoldenTestSuite struct {
+	suite.Suite
+
+	s stats.Provider
+}
+
+func (s *GoldenTestSuite) SetupTest() {
+	var (
+		client       = gateway.NewClientWithTransport(time.Second, gateway.NewReplayer(filepath.Join("testdata", "recordings")))
+		leagues, err = nba.NewRegistry(nba.DefaultLeagues("https://stats.nba.test", "https://cdn.nba.test", "https://cdn.wnba.test"))
+	)
+
+	s.Require().NoError(err)
+
+	s.s = stats.NewService(stats.NewNBAProvider(nba.New(client, leagues), storage.Noop{}), leagues)
+}
+
+func TestGolden(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(GoldenTestSuite))
+}
+
+func (s *GoldenTestSuite) TestGetScoreboard() {
+	res, err := s.s.GetScoreboard(context.Background(), nba.GetScoreboardCommand{Date: "2022-10-18", LeagueID: nba.NBA})
+	s.Require().NoError(err)
+
+	s.assertGolden("scoreboard_00_2022-10-18.json", res)
+}
+
+func (s *GoldenTestSuite) TestGetBoxscore() {
+	for _, gameID := range []string{"0022200001", "0022200002"} {
+		res, err := s.s.GetBoxscore(context.Background(), nba.GetBoxscoreCommand{GameID: gameID, LeagueID: nba.NBA})
+		s.Require().NoError(err)
+
+		s.assertGolden("boxscore_"+gameID+".json", res)
+	}
+
+	_, err := s.s.GetBoxscore(context.Background(), nba.GetBoxscoreCommand{GameID: "0022299999", LeagueID: nba.NBA})
+	s.Error(err)
+}
+
+func (s *GoldenTestSuite) assertGolden(name string, v any) {
+	path := filepath.Join("testdata", "golden", name)
+
+	got, err := json.MarshalIndent(v, "", "  ")
+	s.Require().NoError(err)
+
+	if *update {
+		s.Require().NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+		s.Require().NoError(os.WriteFile(path, append(got, '\n'), 0o644))
+	}
+
+	exp, err := os.ReadFile(path)
+	s.Require().NoError(err)
+
+	s.JSONEq(string(exp), string(got))
+}
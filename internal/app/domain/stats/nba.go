@@ -0,0 +1,113 @@
+package stats
+
+import (
+	"context"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/logging"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/storage"
+)
+
+// NBAProvider serves the leagues of the NBA APIs, keeping finished games in
+// the store
+type NBAProvider struct {
+	a  nba.API
+	st storage.Store
+}
+
+func NewNBAProvider(a nba.API, st storage.Store) *NBAProvider { return &NBAProvider{a: a, st: st} }
+
+func (p *NBAProvider) GetScoreboard(ctx context.Context, cmd nba.GetScoreboardCommand) (Scoreboard, error) {
+	sb, err := p.getScoreboard(ctx, cmd)
+	if err != nil {
+		return Scoreboard{}, err
+	}
+
+	return NewScoreboard(sb), nil
+}
+
+func (p *NBAProvider) GetBoxscore(ctx context.Context, cmd nba.GetBoxscoreCommand) (Boxscore, error) {
+	bs, err := p.getBoxscore(ctx, cmd)
+	if err != nil {
+		return Boxscore{}, err
+	}
+
+	return NewBoxscore(bs), nil
+}
+
+// GetSchedule is always fetched, the schedule changes until the season is over
+func (p *NBAProvider) GetSchedule(ctx context.Context, cmd nba.GetScheduleCommand) (Schedule, error) {
+	sd, err := p.a.GetSchedule(ctx, cmd)
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	return NewSchedule(sd), nil
+}
+
+// getScoreboard reads the scoreboard from the store and only falls back to the
+// gateway when it is missing. Scoreboards are persisted once every game is over.
+// The store is a cache, its errors are logged but never fail the request.
+func (p *NBAProvider) getScoreboard(ctx context.Context, cmd nba.GetScoreboardCommand) (nba.ScoreboardData, error) {
+	// Without a date the upstream returns today's scoreboard, which can't be keyed.
+	if cmd.Date == "" {
+		return p.a.GetScoreboard(ctx, cmd)
+	}
+
+	sb, ok, err := p.st.GetScoreboard(ctx, cmd)
+	if err != nil {
+		logging.FromContext(ctx).Warnw("failed to read scoreboard from the store", "date", cmd.Date, "err", err)
+	}
+
+	if err == nil && ok {
+		logging.FromContext(ctx).Debugw("scoreboard read from the store", "date", cmd.Date)
+
+		return sb, nil
+	}
+
+	sb, err = p.a.GetScoreboard(ctx, cmd)
+	if err != nil {
+		return nba.ScoreboardData{}, err
+	}
+
+	if sb.Scoreboard.IsFinal() {
+		logging.FromContext(ctx).Debugw("storing final scoreboard", "date", cmd.Date)
+
+		if err := p.st.PutScoreboard(ctx, cmd, sb); err != nil {
+			logging.FromContext(ctx).Warnw("failed to store final scoreboard", "date", cmd.Date, "err", err)
+		}
+	}
+
+	return sb, nil
+}
+
+// getBoxscore reads the boxscore from the store and only falls back to the
+// gateway when it is missing. Boxscores are persisted once the game is over.
+// Like with scoreboards, store errors are only logged.
+func (p *NBAProvider) getBoxscore(ctx context.Context, cmd nba.GetBoxscoreCommand) (nba.BoxscoreData, error) {
+	bs, ok, err := p.st.GetBoxscore(ctx, cmd)
+	if err != nil {
+		logging.FromContext(ctx).Warnw("failed to read boxscore from the store", "game_id", cmd.GameID, "err", err)
+	}
+
+	if err == nil && ok {
+		logging.FromContext(ctx).Debugw("boxscore read from the store", "game_id", cmd.GameID)
+
+		return bs, nil
+	}
+
+	bs, err = p.a.GetBoxscore(ctx, cmd)
+	if err != nil {
+		return nba.BoxscoreData{}, err
+	}
+
+	if bs.Boxscore.IsFinal() {
+		logging.FromContext(ctx).Debugw("storing final boxscore", "game_id", cmd.GameID)
+
+		if err := p.st.PutBoxscore(ctx, cmd, bs); err != nil {
+			logging.FromContext(ctx).Warnw("failed to store final boxscore", "game_id", cmd.GameID, "err", err)
+		}
+	}
+
+	return bs, nil
+}
@@ -0,0 +1,83 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// PerGame leaves stat lines as they are
+	PerGame Per = ""
+	// Per36 scales stat lines to 36 minutes played
+	Per36 Per = "36"
+	// Per100Possessions scales stat lines to 100 possessions played
+	Per100Possessions Per = "100poss"
+
+	// playersOnCourt is how many players of a team are playing at a time, the
+	// seconds of a team line are the sum of theirs
+	playersOnCourt = 5
+)
+
+// ErrInvalidPer is returned for unknown normalisations
+var ErrInvalidPer = errors.New("invalid per")
+
+// Per is how the counting stats of a line are normalised
+type Per string
+
+// ParsePer parses the per query param, 36 or 100poss
+func ParsePer(s string) (Per, error) {
+	switch p := Per(s); p {
+	case PerGame, Per36, Per100Possessions:
+		return p, nil
+	default:
+		return "", fmt.Errorf("%w %q, must be 36 or 100poss", ErrInvalidPer, s)
+	}
+}
+
+// Possessions estimates the possessions each team had in a game, averaging
+// the estimates of both so they are the same
+func Possessions(team, opp Stats) float64 {
+	return (possessions(team) + possessions(opp)) / 2
+}
+
+func possessions(s Stats) float64 {
+	return float64(s.FGA) + 0.44*float64(s.FTA) - float64(s.RO) + float64(s.TO)
+}
+
+// TeamFactor is what the counting stats of the team line are multiplied by
+func (p Per) TeamFactor(team, opp Stats) float64 {
+	return p.factor(team.SecondsPlayed/playersOnCourt, team, opp)
+}
+
+// PlayerFactor is what the counting stats of a player line are multiplied by,
+// from the line of the player's team and the opponent's
+func (p Per) PlayerFactor(player, team, opp Stats) float64 {
+	return p.factor(player.SecondsPlayed, team, opp)
+}
+
+// factor scales a line of the given seconds played. Lines without any are
+// zeroed.
+func (p Per) factor(seconds float64, team, opp Stats) float64 {
+	switch p {
+	case Per36:
+		if seconds <= 0 {
+			return 0
+		}
+
+		return 36 * 60 / seconds
+	case Per100Possessions:
+		if seconds <= 0 || team.SecondsPlayed <= 0 {
+			return 0
+		}
+
+		// Possessions while on court, assuming the pace of the whole game.
+		poss := Possessions(team, opp) * seconds / (team.SecondsPlayed / playersOnCourt)
+		if poss <= 0 {
+			return 0
+		}
+
+		return 100 / poss
+	default:
+		return 1
+	}
+}
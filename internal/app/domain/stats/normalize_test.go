@@ -0,0 +1,96 @@
+package stats_test
+
+import (
+	"testing"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinutes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		scenario string
+
+		minutes string
+
+		expMinutes string
+		expSeconds float64
+	}{
+		{scenario: "player", minutes: "PT25M12.02S", expMinutes: "25:12", expSeconds: 1512.02},
+		{scenario: "leading zero", minutes: "PT04M05.00S", expMinutes: "4:05", expSeconds: 245},
+		{scenario: "team total", minutes: "PT240M", expMinutes: "240:00", expSeconds: 14400},
+		{scenario: "team total with overtime", minutes: "PT265M00.00S", expMinutes: "265:00", expSeconds: 15900},
+		{scenario: "did not play", minutes: "", expMinutes: "0:00"},
+		{scenario: "unknown format", minutes: "25:12", expMinutes: "0:00"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			b := stats.NewBoxscore(nba.BoxscoreData{Boxscore: nba.Boxscore{HomeTeam: nba.Team{Stats: nba.Stats{Minutes: tt.minutes}}}})
+
+			assert.Equal(t, tt.expMinutes, b.HomeTeam.Stats.Minutes)
+			assert.Equal(t, tt.expSeconds, b.HomeTeam.Stats.SecondsPlayed)
+		})
+	}
+}
+
+func TestParsePer(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []string{"", "36", "100poss"} {
+		p, err := stats.ParsePer(s)
+		assert.NoError(t, err)
+		assert.Equal(t, stats.Per(s), p)
+	}
+
+	_, err := stats.ParsePer("48")
+	assert.ErrorIs(t, err, stats.ErrInvalidPer)
+}
+
+func TestPerFactor(t *testing.T) {
+	t.Parallel()
+
+	var (
+		// 80 + 0.44*25 - 10 + 14 = 95 and 90 - 5 + 15 = 100 possessions
+		team    = stats.Stats{SecondsPlayed: 14400, FGA: 80, FTA: 25, RO: 10, TO: 14}
+		opp     = stats.Stats{SecondsPlayed: 14400, FGA: 90, RO: 5, TO: 15}
+		player  = stats.Stats{SecondsPlayed: 1440}
+		benched = stats.Stats{}
+	)
+
+	assert.InDelta(t, 97.5, stats.Possessions(team, opp), 1e-9)
+
+	tests := []struct {
+		scenario string
+
+		per stats.Per
+
+		expTeam    float64
+		expPlayer  float64
+		expBenched float64
+	}{
+		{scenario: "per game", per: stats.PerGame, expTeam: 1, expPlayer: 1, expBenched: 1},
+		{scenario: "per 36 minutes", per: stats.Per36, expTeam: 0.75, expPlayer: 1.5},
+		// Half the game on court is half of the possessions.
+		{scenario: "per 100 possessions", per: stats.Per100Possessions, expTeam: 100 / 97.5, expPlayer: 100 / 48.75},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			assert.InDelta(t, tt.expTeam, tt.per.TeamFactor(team, opp), 1e-9)
+			assert.InDelta(t, tt.expPlayer, tt.per.PlayerFactor(player, team, opp), 1e-9)
+			assert.InDelta(t, tt.expBenched, tt.per.PlayerFactor(benched, team, opp), 1e-9)
+		})
+	}
+}
@@ -2,38 +2,108 @@ package stats
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats")
+
+// ErrRateLimited is returned by providers charging the client of the request
+// once it runs out of tokens
+var ErrRateLimited = errors.New("rate limit exceeded")
+
 type (
+	// Provider serves the scoreboards and box scores of leagues in the shape
+	// of the API, whatever feed they come from
 	Provider interface {
 		GetScoreboard(context.Context, nba.GetScoreboardCommand) (Scoreboard, error)
 		GetBoxscore(context.Context, nba.GetBoxscoreCommand) (Boxscore, error)
+		GetSchedule(context.Context, nba.GetScheduleCommand) (Schedule, error)
 	}
 
+	// Service serves every league from the provider registered for it, the
+	// default one for the rest
 	Service struct {
-		a nba.API
+		def       Provider
+		leagues   *nba.Registry
+		providers map[nba.LeagueID]Provider
 	}
+
+	// Option registers league providers on the Service
+	Option func(*Service)
 )
 
-func NewService(a nba.API) *Service { return &Service{a} }
+// NewService serves the leagues of the registry from def, unless another
+// provider is registered for them
+func NewService(def Provider, leagues *nba.Registry, opts ...Option) *Service {
+	s := &Service{def: def, leagues: leagues, providers: make(map[nba.LeagueID]Provider)}
 
-func (s *Service) GetScoreboard(ctx context.Context, cmd nba.GetScoreboardCommand) (Scoreboard, error) {
-	sb, err := s.a.GetScoreboard(ctx, cmd)
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// WithLeagueProvider serves the league from p instead of the default provider
+func WithLeagueProvider(league nba.LeagueID, p Provider) Option {
+	return func(s *Service) { s.providers[league] = p }
+}
+
+func (s *Service) GetScoreboard(ctx context.Context, cmd nba.GetScoreboardCommand) (_ Scoreboard, err error) {
+	ctx, span := tracer.Start(ctx, "stats.GetScoreboard", trace.WithAttributes(
+		attribute.String("nba.league", s.leagues.Get(cmd.LeagueID).Name),
+		attribute.String("nba.date", cmd.Date),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	sb, err := s.provider(cmd.LeagueID).GetScoreboard(ctx, cmd)
 	if err != nil {
 		return Scoreboard{}, fmt.Errorf("failed to get scoreboard: %w", err)
 	}
 
-	return NewScoreboard(sb), nil
+	return sb, nil
 }
 
-func (s *Service) GetBoxscore(ctx context.Context, cmd nba.GetBoxscoreCommand) (Boxscore, error) {
-	bs, err := s.a.GetBoxscore(ctx, cmd)
+func (s *Service) GetBoxscore(ctx context.Context, cmd nba.GetBoxscoreCommand) (_ Boxscore, err error) {
+	ctx, span := tracer.Start(ctx, "stats.GetBoxscore", trace.WithAttributes(
+		attribute.String("nba.league", s.leagues.Get(cmd.LeagueID).Name),
+		attribute.String("nba.game_id", cmd.GameID),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	bs, err := s.provider(cmd.LeagueID).GetBoxscore(ctx, cmd)
 	if err != nil {
 		return Boxscore{}, fmt.Errorf("failed to get boxscore: %w", err)
 	}
 
-	return NewBoxscore(bs), nil
+	return bs, nil
+}
+
+func (s *Service) GetSchedule(ctx context.Context, cmd nba.GetScheduleCommand) (_ Schedule, err error) {
+	ctx, span := tracer.Start(ctx, "stats.GetSchedule", trace.WithAttributes(
+		attribute.String("nba.league", s.leagues.Get(cmd.LeagueID).Name),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	sc, err := s.provider(cmd.LeagueID).GetSchedule(ctx, cmd)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("failed to get schedule: %w", err)
+	}
+
+	return sc, nil
+}
+
+func (s *Service) provider(league nba.LeagueID) Provider {
+	if p, ok := s.providers[league]; ok {
+		return p
+	}
+
+	return s.def
 }
@@ -0,0 +1,32 @@
+package stats
+
+import (
+	"context"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/stretchr/testify/mock"
+)
+
+// ProviderMock mock
+type ProviderMock struct{ mock.Mock }
+
+// GetScoreboard mock
+func (m *ProviderMock) GetScoreboard(ctx context.Context, cmd nba.GetScoreboardCommand) (Scoreboard, error) {
+	args := m.Called(ctx, cmd)
+
+	return args.Get(0).(Scoreboard), args.Error(1)
+}
+
+// GetBoxscore mock
+func (m *ProviderMock) GetBoxscore(ctx context.Context, cmd nba.GetBoxscoreCommand) (Boxscore, error) {
+	args := m.Called(ctx, cmd)
+
+	return args.Get(0).(Boxscore), args.Error(1)
+}
+
+// GetSchedule mock
+func (m *ProviderMock) GetSchedule(ctx context.Context, cmd nba.GetScheduleCommand) (Schedule, error) {
+	args := m.Called(ctx, cmd)
+
+	return args.Get(0).(Schedule), args.Error(1)
+}
@@ -8,22 +8,41 @@ import (
 
 	"github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats"
 	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba/nbatest"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/storage"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
-var errFailed = errors.New("failed")
+var (
+	errFailed = errors.New("failed")
+
+	leagues = nbatest.Leagues("https://nba.test")
+
+	finalScoreboard = nba.ScoreboardData{
+		Scoreboard: nba.Scoreboard{
+			Games: []nba.Game{{ID: "0022200001", Status: nba.Final}},
+		},
+	}
+
+	finalBoxscore = nba.BoxscoreData{
+		Boxscore: nba.Boxscore{ID: "0022200001", Status: nba.Final},
+	}
+)
 
 type ServiceTestSuite struct {
 	suite.Suite
 
 	nm *nba.APIMock
+	sm *storage.StoreMock
 	s  stats.Provider
 }
 
 func (s *ServiceTestSuite) SetupTest() {
 	s.nm = new(nba.APIMock)
+	s.sm = new(storage.StoreMock)
 
-	s.s = stats.NewService(s.nm)
+	s.s = stats.NewService(stats.NewNBAProvider(s.nm, s.sm), leagues)
 }
 
 func TestStatsService(t *testing.T) {
@@ -36,12 +55,32 @@ func (s *ServiceTestSuite) TestGetScoreboard() {
 	tests := []struct {
 		scenario string
 
+		stored   nba.ScoreboardData
+		found    bool
+		storeErr error
+
 		sb     nba.ScoreboardData
 		nbaErr error
 
+		putErr error
+		expPut bool
+
 		expErr error
 		expRes stats.Scoreboard
 	}{
+		{
+			scenario: "failed to read scoreboard from store",
+			storeErr: errFailed,
+			sb:       finalScoreboard,
+			expPut:   true,
+			expRes:   stats.NewScoreboard(finalScoreboard),
+		},
+		{
+			scenario: "read scoreboard from store",
+			stored:   finalScoreboard,
+			found:    true,
+			expRes:   stats.NewScoreboard(finalScoreboard),
+		},
 		{
 			scenario: "failed to fetch scoreboard from nba api",
 			nbaErr:   errFailed,
@@ -54,6 +93,19 @@ func (s *ServiceTestSuite) TestGetScoreboard() {
 				Games: []stats.Game{},
 			},
 		},
+		{
+			scenario: "failed to store final scoreboard",
+			sb:       finalScoreboard,
+			putErr:   errFailed,
+			expPut:   true,
+			expRes:   stats.NewScoreboard(finalScoreboard),
+		},
+		{
+			scenario: "fetch and store final scoreboard",
+			sb:       finalScoreboard,
+			expPut:   true,
+			expRes:   stats.NewScoreboard(finalScoreboard),
+		},
 	}
 
 	for _, tt := range tests {
@@ -71,12 +123,18 @@ func (s *ServiceTestSuite) TestGetScoreboard() {
 				}
 			)
 
-			s.nm.On("GetScoreboard", ctx, cmd).Return(tt.sb, tt.nbaErr)
+			s.sm.On("GetScoreboard", mock.Anything, cmd).Return(tt.stored, tt.found, tt.storeErr)
+			s.nm.On("GetScoreboard", mock.Anything, cmd).Return(tt.sb, tt.nbaErr)
+			s.sm.On("PutScoreboard", mock.Anything, cmd, tt.sb).Return(tt.putErr)
 
 			res, err := s.s.GetScoreboard(ctx, cmd)
 
 			s.Equal(tt.expErr, err)
 			s.Equal(tt.expRes, res)
+
+			if !tt.expPut {
+				s.sm.AssertNotCalled(s.T(), "PutScoreboard", mock.Anything, mock.Anything, mock.Anything)
+			}
 		})
 	}
 }
@@ -85,12 +143,45 @@ func (s *ServiceTestSuite) TestGetBoxscore() {
 	tests := []struct {
 		scenario string
 
+		stored   nba.BoxscoreData
+		found    bool
+		storeErr error
+
 		nbaData nba.BoxscoreData
 		nbaErr  error
 
+		putErr error
+		expPut bool
+
 		expErr error
 		expRes stats.Boxscore
 	}{
+		{
+			scenario: "failed to read boxscore from store",
+			storeErr: errFailed,
+			nbaData:  finalBoxscore,
+			expPut:   true,
+			expRes:   stats.NewBoxscore(finalBoxscore),
+		},
+		{
+			scenario: "read boxscore from store",
+			stored:   finalBoxscore,
+			found:    true,
+			expRes:   stats.NewBoxscore(finalBoxscore),
+		},
+		{
+			scenario: "failed to store final boxscore",
+			nbaData:  finalBoxscore,
+			putErr:   errFailed,
+			expPut:   true,
+			expRes:   stats.NewBoxscore(finalBoxscore),
+		},
+		{
+			scenario: "fetch and store final boxscore",
+			nbaData:  finalBoxscore,
+			expPut:   true,
+			expRes:   stats.NewBoxscore(finalBoxscore),
+		},
 		{
 			scenario: "failed to fetch boxscore from nba api",
 			nbaErr:   errFailed,
@@ -131,12 +222,42 @@ func (s *ServiceTestSuite) TestGetBoxscore() {
 				}
 			)
 
-			s.nm.On("GetBoxscore", ctx, cmd).Return(tt.nbaData, tt.nbaErr)
+			s.sm.On("GetBoxscore", mock.Anything, cmd).Return(tt.stored, tt.found, tt.storeErr)
+			s.nm.On("GetBoxscore", mock.Anything, cmd).Return(tt.nbaData, tt.nbaErr)
+			s.sm.On("PutBoxscore", mock.Anything, cmd, tt.nbaData).Return(tt.putErr)
 
 			res, err := s.s.GetBoxscore(ctx, cmd)
 
 			s.Equal(tt.expErr, err)
 			s.Equal(tt.expRes, res)
+
+			if !tt.expPut {
+				s.sm.AssertNotCalled(s.T(), "PutBoxscore", mock.Anything, mock.Anything, mock.Anything)
+			}
 		})
 	}
 }
+
+func (s *ServiceTestSuite) TestLeagueProvider() {
+	var (
+		ctx = context.Background()
+		pm  = new(stats.ProviderMock)
+		svc = stats.NewService(stats.NewNBAProvider(s.nm, s.sm), leagues, stats.WithLeagueProvider(nba.WNBA, pm))
+
+		wnba = nba.GetBoxscoreCommand{GameID: "1022300001", LeagueID: nba.WNBA}
+		cmd  = nba.GetBoxscoreCommand{GameID: "0022200001", LeagueID: nba.NBA}
+	)
+
+	pm.On("GetBoxscore", mock.Anything, wnba).Return(stats.Boxscore{GameID: wnba.GameID}, nil)
+	s.sm.On("GetBoxscore", mock.Anything, cmd).Return(finalBoxscore, true, nil)
+
+	res, err := svc.GetBoxscore(ctx, wnba)
+	s.NoError(err)
+	s.Equal(stats.Boxscore{GameID: wnba.GameID}, res)
+
+	res, err = svc.GetBoxscore(ctx, cmd)
+	s.NoError(err)
+	s.Equal(stats.NewBoxscore(finalBoxscore), res)
+
+	s.nm.AssertNotCalled(s.T(), "GetBoxscore", mock.Anything, mock.Anything)
+}
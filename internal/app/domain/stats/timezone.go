@@ -0,0 +1,66 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+)
+
+const dateFormat = "2006-01-02"
+
+// ErrInvalidDate is returned for dates not in the 2006-01-02 format
+var ErrInvalidDate = errors.New("invalid date")
+
+// GetLocalScoreboard is the scoreboard of a day in loc, the games starting on
+// that day there. Upstream days are in the timezone of the league of the
+// command, so a day elsewhere can span two of them, e.g. the night of a
+// European user. Without a date it is today in loc.
+func GetLocalScoreboard(ctx context.Context, p Provider, cmd nba.GetScoreboardCommand, league nba.League, loc *time.Location, now time.Time) (Scoreboard, error) {
+	var (
+		start time.Time
+		err   error
+	)
+
+	if cmd.Date == "" {
+		y, m, d := now.In(loc).Date()
+		start = time.Date(y, m, d, 0, 0, 0, 0, loc)
+	} else if start, err = time.ParseInLocation(dateFormat, cmd.Date, loc); err != nil {
+		return Scoreboard{}, fmt.Errorf("%w %q: %w", ErrInvalidDate, cmd.Date, err)
+	}
+
+	var (
+		end       = start.AddDate(0, 0, 1)
+		leagueLoc = league.Location()
+	)
+
+	sb := Scoreboard{Date: nba.GameDate(time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC))}
+
+	for day := leagueDay(start, leagueLoc); !day.After(leagueDay(end.Add(-time.Nanosecond), leagueLoc)); day = day.AddDate(0, 0, 1) {
+		res, err := p.GetScoreboard(ctx, nba.GetScoreboardCommand{Date: day.Format(dateFormat), LeagueID: cmd.LeagueID})
+		if err != nil {
+			return Scoreboard{}, err
+		}
+
+		for _, g := range res.Games {
+			if t := time.Time(g.StartsAt); !t.Before(start) && t.Before(end) {
+				sb.Games = append(sb.Games, g)
+			}
+		}
+	}
+
+	if sb.Games == nil {
+		sb.Games = []Game{}
+	}
+
+	return sb, nil
+}
+
+// leagueDay is the upstream day t is on, at midnight UTC to step through days
+func leagueDay(t time.Time, leagueLoc *time.Location) time.Time {
+	y, m, d := t.In(leagueLoc).Date()
+
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
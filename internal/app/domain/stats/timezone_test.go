@@ -0,0 +1,121 @@
+package stats_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// games of two Eastern days, the first starting in the European evening
+var (
+	oct18 = stats.Scoreboard{Games: []stats.Game{
+		{ID: "0022200001", StartsAt: nba.GameTime(time.Date(2022, 10, 18, 23, 30, 0, 0, time.UTC))},
+		{ID: "0022200002", StartsAt: nba.GameTime(time.Date(2022, 10, 19, 2, 0, 0, 0, time.UTC))},
+	}}
+	oct19 = stats.Scoreboard{Games: []stats.Game{
+		{ID: "0022200003", StartsAt: nba.GameTime(time.Date(2022, 10, 19, 22, 30, 0, 0, time.UTC))},
+		{ID: "0022200004", StartsAt: nba.GameTime(time.Date(2022, 10, 20, 2, 30, 0, 0, time.UTC))},
+	}}
+)
+
+func TestGetLocalScoreboard(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		scenario string
+
+		date string
+		tz   string
+		now  time.Time
+
+		expDate  string
+		expDays  []string
+		expGames []string
+	}{
+		{
+			scenario: "eastern day",
+			date:     "2022-10-18",
+			tz:       "America/New_York",
+			expDate:  "2022-10-18",
+			expDays:  []string{"2022-10-18"},
+			expGames: []string{"0022200001", "0022200002"},
+		},
+		{
+			scenario: "european night spans two eastern days",
+			date:     "2022-10-19",
+			tz:       "Europe/Lisbon",
+			expDate:  "2022-10-19",
+			expDays:  []string{"2022-10-18", "2022-10-19"},
+			expGames: []string{"0022200001", "0022200002", "0022200003"},
+		},
+		{
+			scenario: "pacific day",
+			date:     "2022-10-18",
+			tz:       "America/Los_Angeles",
+			expDate:  "2022-10-18",
+			expDays:  []string{"2022-10-18", "2022-10-19"},
+			expGames: []string{"0022200001", "0022200002"},
+		},
+		{
+			scenario: "today in the timezone",
+			tz:       "Asia/Tokyo",
+			now:      time.Date(2022, 10, 19, 16, 0, 0, 0, time.UTC),
+			expDate:  "2022-10-20",
+			expDays:  []string{"2022-10-19", "2022-10-20"},
+			expGames: []string{"0022200003", "0022200004"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			pm := new(stats.ProviderMock)
+			pm.On("GetScoreboard", mock.Anything, nba.GetScoreboardCommand{Date: "2022-10-18", LeagueID: nba.NBA}).Return(oct18, nil).Maybe()
+			pm.On("GetScoreboard", mock.Anything, nba.GetScoreboardCommand{Date: "2022-10-19", LeagueID: nba.NBA}).Return(oct19, nil).Maybe()
+			pm.On("GetScoreboard", mock.Anything, nba.GetScoreboardCommand{Date: "2022-10-20", LeagueID: nba.NBA}).Return(stats.Scoreboard{}, nil).Maybe()
+
+			loc, err := time.LoadLocation(tt.tz)
+			require.NoError(t, err)
+
+			sb, err := stats.GetLocalScoreboard(context.Background(), pm, nba.GetScoreboardCommand{Date: tt.date, LeagueID: nba.NBA}, leagues.Get(nba.NBA), loc, tt.now)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expDate, time.Time(sb.Date).Format("2006-01-02"))
+
+			games := make([]string, len(sb.Games))
+			for i, g := range sb.Games {
+				games[i] = g.ID
+			}
+
+			assert.Equal(t, tt.expGames, games)
+
+			for _, day := range tt.expDays {
+				pm.AssertCalled(t, "GetScoreboard", mock.Anything, nba.GetScoreboardCommand{Date: day, LeagueID: nba.NBA})
+			}
+
+			pm.AssertNumberOfCalls(t, "GetScoreboard", len(tt.expDays))
+		})
+	}
+}
+
+func TestGetLocalScoreboardErrors(t *testing.T) {
+	t.Parallel()
+
+	pm := new(stats.ProviderMock)
+	pm.On("GetScoreboard", mock.Anything, mock.Anything).Return(stats.Scoreboard{}, errFailed)
+
+	_, err := stats.GetLocalScoreboard(context.Background(), pm, nba.GetScoreboardCommand{Date: "18/10/2022"}, leagues.Get(nba.NBA), time.UTC, time.Time{})
+	assert.ErrorIs(t, err, stats.ErrInvalidDate)
+
+	_, err = stats.GetLocalScoreboard(context.Background(), pm, nba.GetScoreboardCommand{Date: "2022-10-18"}, leagues.Get(nba.NBA), time.UTC, time.Time{})
+	assert.ErrorIs(t, err, errFailed)
+}
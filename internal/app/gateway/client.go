@@ -7,5 +7,11 @@ import (
 
 // NewClientWithTimeout create an http.Client with timeout.
 func NewClientWithTimeout(timeout time.Duration) *http.Client {
-	return &http.Client{Timeout: timeout}
+	return NewClientWithTransport(timeout, nil)
+}
+
+// NewClientWithTransport create an http.Client with timeout that sends requests
+// through rt. A nil rt uses http.DefaultTransport.
+func NewClientWithTransport(timeout time.Duration, rt http.RoundTripper) *http.Client {
+	return &http.Client{Timeout: timeout, Transport: rt}
 }
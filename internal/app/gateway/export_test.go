@@ -0,0 +1,22 @@
+package gateway
+
+// GiveUpAfterHandover queues a request for host that gives up once the token
+// is handed over to it, as when its context is done at the same time.
+func (l *Limiter) GiveUpAfterHandover(host string) {
+	l.mu.Lock()
+
+	h := l.hosts[host]
+	w := &waiter{ready: make(chan struct{})}
+	h.queues[PriorityInteractive] = append(h.queues[PriorityInteractive], w)
+
+	if !h.running {
+		h.running = true
+
+		go l.dispatch(h)
+	}
+
+	l.mu.Unlock()
+
+	<-w.ready
+	l.giveUp(h, PriorityInteractive, w)
+}
@@ -0,0 +1,320 @@
+package gateway
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// PriorityInteractive is for requests someone is waiting on. It is the
+	// default.
+	PriorityInteractive Priority = iota
+	// PriorityBackground is for jobs and polling, only served when no
+	// interactive request is queued.
+	PriorityBackground
+)
+
+// WaitBuckets are the upper bounds of the wait histogram of WaitStats. Longer
+// waits are only in Requests.
+var WaitBuckets = []time.Duration{
+	0,
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+}
+
+type (
+	// Priority orders the requests queued for an upstream
+	Priority int
+
+	priorityKey struct{}
+
+	// HostLimit is the token bucket of an upstream host
+	HostLimit struct {
+		// PerSecond is the rate tokens are added at
+		PerSecond float64
+		Burst     int
+	}
+
+	// WaitStats sums up how long requests queued for a host
+	WaitStats struct {
+		Host     string
+		Priority Priority
+		// Requests is every request that went through the limiter
+		Requests int64
+		// Queued is the requests that had to wait for a token
+		Queued int64
+		Total  time.Duration
+		// Waits counts the requests that waited up to each of WaitBuckets, and
+		// not longer than the one before
+		Waits []int64
+	}
+
+	// Limiter paces the requests to every upstream host with a token bucket
+	// shared by all callers. Queued interactive requests always get the next
+	// token before background ones. Hosts without limits are not paced.
+	Limiter struct {
+		mu    sync.Mutex
+		hosts map[string]*hostLimiter
+		stats map[statsKey]*WaitStats
+	}
+
+	statsKey struct {
+		host     string
+		priority Priority
+	}
+
+	hostLimiter struct {
+		limiter *rate.Limiter
+		queues  [2][]*waiter
+		running bool
+		// spare are tokens handed to requests that gave up, for the next ones
+		spare int
+	}
+
+	waiter struct {
+		ready chan struct{}
+	}
+)
+
+// String is the metrics label of the priority
+func (p Priority) String() string {
+	if p == PriorityBackground {
+		return "background"
+	}
+
+	return "interactive"
+}
+
+// WithPriority sets the priority of the upstream requests made with ctx
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// PriorityFromContext returns the priority set with WithPriority, interactive
+// by default
+func PriorityFromContext(ctx context.Context) Priority {
+	if p, _ := ctx.Value(priorityKey{}).(Priority); p == PriorityBackground {
+		return p
+	}
+
+	return PriorityInteractive
+}
+
+// NewLimiter creates a Limiter with the limits of every host. Hosts with no
+// rate are not paced.
+func NewLimiter(limits map[string]HostLimit) *Limiter {
+	l := &Limiter{hosts: make(map[string]*hostLimiter, len(limits)), stats: make(map[statsKey]*WaitStats)}
+
+	for host, hl := range limits {
+		if hl.PerSecond <= 0 {
+			continue
+		}
+
+		l.hosts[host] = &hostLimiter{limiter: rate.NewLimiter(rate.Limit(hl.PerSecond), max(hl.Burst, 1))}
+	}
+
+	return l
+}
+
+// Wait blocks until a request to host is allowed, or ctx is done. The
+// priority is taken from ctx.
+func (l *Limiter) Wait(ctx context.Context, host string) error {
+	var (
+		p     = PriorityFromContext(ctx)
+		start = time.Now()
+	)
+
+	l.mu.Lock()
+
+	h, ok := l.hosts[host]
+	if !ok {
+		l.mu.Unlock()
+
+		return nil
+	}
+
+	if h.empty() && (h.takeSpare() || h.limiter.Allow()) {
+		l.record(host, p, 0, false)
+		l.mu.Unlock()
+
+		return nil
+	}
+
+	w := &waiter{ready: make(chan struct{})}
+	h.queues[p] = append(h.queues[p], w)
+
+	if !h.running {
+		h.running = true
+
+		go l.dispatch(h)
+	}
+
+	l.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		l.mu.Lock()
+		l.record(host, p, time.Since(start), true)
+		l.mu.Unlock()
+
+		return nil
+	case <-ctx.Done():
+		l.giveUp(h, p, w)
+
+		return ctx.Err()
+	}
+}
+
+// giveUp takes w out of the queue. When the token was handed over to it
+// meanwhile, the token goes to the next request instead.
+func (l *Limiter) giveUp(h *hostLimiter, p Priority, w *waiter) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if h.remove(p, w) {
+		return
+	}
+
+	if next := h.pop(); next != nil {
+		close(next.ready)
+
+		return
+	}
+
+	h.spare = min(h.spare+1, h.limiter.Burst())
+}
+
+// Stats returns the wait stats of every host and priority
+func (l *Limiter) Stats() []WaitStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	stats := make([]WaitStats, 0, len(l.stats))
+	for _, s := range l.stats {
+		c := *s
+		c.Waits = append([]int64(nil), s.Waits...)
+		stats = append(stats, c)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Host != stats[j].Host {
+			return stats[i].Host < stats[j].Host
+		}
+
+		return stats[i].Priority < stats[j].Priority
+	})
+
+	return stats
+}
+
+// dispatch hands tokens to the queued requests, highest priority first, until
+// the queues are empty
+func (l *Limiter) dispatch(h *hostLimiter) {
+	for {
+		l.mu.Lock()
+
+		if h.empty() {
+			h.running = false
+			l.mu.Unlock()
+
+			return
+		}
+
+		if !h.takeSpare() {
+			l.mu.Unlock()
+
+			// Wait never fails without a deadline and with a burst of at least one.
+			h.limiter.Wait(context.Background()) //nolint: errcheck
+
+			l.mu.Lock()
+		}
+
+		// The requests may have given up while waiting for the token, then it
+		// is kept for the next one instead of lost.
+		if w := h.pop(); w != nil {
+			close(w.ready)
+		} else {
+			h.spare = min(h.spare+1, h.limiter.Burst())
+		}
+
+		l.mu.Unlock()
+	}
+}
+
+func (l *Limiter) record(host string, p Priority, wait time.Duration, queued bool) {
+	k := statsKey{host: host, priority: p}
+
+	s, ok := l.stats[k]
+	if !ok {
+		s = &WaitStats{Host: host, Priority: p, Waits: make([]int64, len(WaitBuckets))}
+		l.stats[k] = s
+	}
+
+	s.Requests++
+	s.Total += wait
+
+	for i, b := range WaitBuckets {
+		if wait <= b {
+			s.Waits[i]++
+
+			break
+		}
+	}
+
+	if queued {
+		s.Queued++
+	}
+}
+
+func (h *hostLimiter) empty() bool {
+	return len(h.queues[PriorityInteractive])+len(h.queues[PriorityBackground]) == 0
+}
+
+func (h *hostLimiter) pop() *waiter {
+	for p := range h.queues {
+		if len(h.queues[p]) > 0 {
+			w := h.queues[p][0]
+			h.queues[p] = h.queues[p][1:]
+
+			return w
+		}
+	}
+
+	return nil
+}
+
+// remove takes w out of the queue. It is false when w was already dispatched.
+func (h *hostLimiter) remove(p Priority, w *waiter) bool {
+	for i, q := range h.queues[p] {
+		if q == w {
+			h.queues[p] = append(h.queues[p][:i], h.queues[p][i+1:]...)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// takeSpare spends a token given back by a request that gave up, if any
+func (h *hostLimiter) takeSpare() bool {
+	if h.spare == 0 {
+		return false
+	}
+
+	h.spare--
+
+	return true
+}
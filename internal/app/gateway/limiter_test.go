@@ -0,0 +1,115 @@
+package gateway_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway"
+	"github.com/stretchr/testify/suite"
+)
+
+const host = "stats.nba.test"
+
+type LimiterTestSuite struct {
+	suite.Suite
+
+	l *gateway.Limiter
+}
+
+func (s *LimiterTestSuite) SetupTest() {
+	s.l = gateway.NewLimiter(map[string]gateway.HostLimit{
+		host:           {PerSecond: 20, Burst: 1},
+		"cdn.nba.test": {},
+	})
+}
+
+func TestLimiter(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(LimiterTestSuite))
+}
+
+func (s *LimiterTestSuite) TestUnlimitedHosts() {
+	ctx := context.Background()
+
+	for i := 0; i < 100; i++ {
+		s.Require().NoError(s.l.Wait(ctx, "cdn.nba.test"))
+		s.Require().NoError(s.l.Wait(ctx, "other.test"))
+	}
+
+	s.Empty(s.l.Stats())
+}
+
+func (s *LimiterTestSuite) TestInteractiveFirst() {
+	ctx := context.Background()
+	s.Require().NoError(s.l.Wait(ctx, host))
+
+	served := make(chan gateway.Priority, 2)
+
+	wait := func(p gateway.Priority) {
+		s.NoError(s.l.Wait(gateway.WithPriority(ctx, p), host))
+		served <- p
+	}
+
+	go wait(gateway.PriorityBackground)
+	time.Sleep(10 * time.Millisecond)
+	go wait(gateway.PriorityInteractive)
+
+	s.Equal(gateway.PriorityInteractive, <-served)
+	s.Equal(gateway.PriorityBackground, <-served)
+
+	stats := s.l.Stats()
+	s.Require().Len(stats, 2)
+	s.Equal(gateway.PriorityInteractive, stats[0].Priority)
+	s.Equal(int64(2), stats[0].Requests)
+	s.Equal(int64(1), stats[0].Queued)
+	s.Equal(int64(1), stats[1].Queued)
+	s.Equal(int64(1), stats[0].Waits[0])
+	s.Zero(stats[1].Waits[0])
+	s.GreaterOrEqual(stats[1].Total, 60*time.Millisecond)
+}
+
+func (s *LimiterTestSuite) TestCancel() {
+	s.Require().NoError(s.l.Wait(context.Background(), host))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+
+	s.ErrorIs(s.l.Wait(ctx, host), context.DeadlineExceeded)
+
+	// The cancelled request doesn't take the next token.
+	start := time.Now()
+	s.Require().NoError(s.l.Wait(context.Background(), host))
+	s.Less(time.Since(start), 100*time.Millisecond)
+}
+
+func (s *LimiterTestSuite) TestCancelAfterHandover() {
+	s.Require().NoError(s.l.Wait(context.Background(), host))
+
+	s.l.GiveUpAfterHandover(host)
+
+	// The token handed over to the request that gave up isn't lost.
+	start := time.Now()
+	s.Require().NoError(s.l.Wait(context.Background(), host))
+	s.Less(time.Since(start), 25*time.Millisecond)
+}
+
+func (s *LimiterTestSuite) TestCancelAllWaiting() {
+	l := gateway.NewLimiter(map[string]gateway.HostLimit{host: {PerSecond: 5, Burst: 1}})
+	s.Require().NoError(l.Wait(context.Background(), host))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+
+	for i := 0; i < 3; i++ {
+		s.ErrorIs(l.Wait(ctx, host), context.DeadlineExceeded)
+	}
+
+	// The token that came up once every request gave up is kept for the next.
+	time.Sleep(250 * time.Millisecond)
+
+	start := time.Now()
+	s.Require().NoError(l.Wait(context.Background(), host))
+	s.Less(time.Since(start), 50*time.Millisecond)
+}
@@ -3,10 +3,32 @@ package nba
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/logging"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/metrics"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba")
+
+// ErrNotFound is the upstream having nothing for the request. The CDN answers
+// 403 rather than 404 for the documents of unknown games.
+var ErrNotFound = errors.New("not found upstream")
+
+// errNotCached is a boxscore the CDN says didn't change, whose document isn't
+// kept anymore
+var errNotCached = errors.New("boxscore not modified but not cached")
+
 type (
 	httpClient interface {
 		Do(*http.Request) (*http.Response, error)
@@ -15,28 +37,58 @@ type (
 	API interface {
 		GetScoreboard(context.Context, GetScoreboardCommand) (ScoreboardData, error)
 		GetBoxscore(context.Context, GetBoxscoreCommand) (BoxscoreData, error)
+		GetSchedule(context.Context, GetScheduleCommand) (ScheduleData, error)
 	}
 
 	// Client is the NBA API client
 	Client struct {
-		baseURL     string
-		cdnURL      string
-		wnbaCdnURL  string
-		client      httpClient
+		client    httpClient
+		leagues   *Registry
+		boxscores *validators
+		limiter   *gateway.Limiter
 	}
+
+	// Option configures the Client
+	Option func(*Client)
 )
 
-// New creates a new instance of Client
-func New(client httpClient, baseURL, cdnURL, wnbaCdnURL string) *Client {
-	return &Client{baseURL: baseURL, cdnURL: cdnURL, client: client, wnbaCdnURL: wnbaCdnURL}
+// WithLimiter paces the requests to every upstream host with l
+func WithLimiter(l *gateway.Limiter) Option {
+	return func(c *Client) { c.limiter = l }
+}
+
+// New creates a new instance of Client, serving the leagues of the registry
+// from their URLs
+func New(client httpClient, leagues *Registry, opts ...Option) *Client {
+	c := &Client{
+		client:    client,
+		leagues:   leagues,
+		boxscores: newValidators(maxValidators),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
 // GetScoreboard get scoreboard for a specific day
-func (c *Client) GetScoreboard(ctx context.Context, cmd GetScoreboardCommand) (ScoreboardData, error) {
+func (c *Client) GetScoreboard(ctx context.Context, cmd GetScoreboardCommand) (sb ScoreboardData, err error) {
+	ctx, span := tracer.Start(ctx, "nba.GetScoreboard", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("nba.league", c.leagues.Get(cmd.LeagueID).Name),
+		attribute.String("nba.date", cmd.Date),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	return c.getScoreboard(ctx, cmd)
+}
+
+func (c *Client) getScoreboard(ctx context.Context, cmd GetScoreboardCommand) (ScoreboardData, error) {
 	req, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodGet,
-		fmt.Sprintf("%s/stats/scoreboardv3", c.baseURL),
+		fmt.Sprintf("%s/stats/scoreboardv3", c.leagues.Get(cmd.LeagueID).StatsBaseURL),
 		nil,
 	)
 	if err != nil {
@@ -48,7 +100,7 @@ func (c *Client) GetScoreboard(ctx context.Context, cmd GetScoreboardCommand) (S
 	q.Set("GameDate", cmd.Date)
 	req.URL.RawQuery = q.Encode()
 
-	resp, err := c.doRequest(req)
+	resp, err := c.doRequest(req, metrics.EndpointScoreboard, cmd.LeagueID)
 	if err != nil {
 		return ScoreboardData{}, fmt.Errorf("failed to do request: %w", err)
 	}
@@ -57,57 +109,242 @@ func (c *Client) GetScoreboard(ctx context.Context, cmd GetScoreboardCommand) (S
 
 	var s ScoreboardData
 	if err := json.NewDecoder(resp.Body).Decode(&s); err != nil {
+		metrics.UpstreamFailed(metrics.EndpointScoreboard, c.leagues.Get(cmd.LeagueID).Name, metrics.ReasonDecode)
+		logging.FromContext(ctx).Warnw("failed to decode upstream response",
+			"endpoint", metrics.EndpointScoreboard, "league", c.leagues.Get(cmd.LeagueID).Name, "err", err)
+
 		return ScoreboardData{}, fmt.Errorf("failed to decode response body: %w", err)
 	}
 
 	return s, nil
 }
 
-// GetScoreboard get scoreboard for a specific day
-func (c *Client) GetBoxscore(ctx context.Context, cmd GetBoxscoreCommand) (BoxscoreData, error) {
-	cdnURL := c.cdnURL
-	if cmd.LeagueID == WNBA {
-		cdnURL = c.wnbaCdnURL
+// GetBoxscore get boxscore for a specific game. The CDN is asked to answer with
+// 304 when the boxscore didn't change since the last call, reusing its document.
+func (c *Client) GetBoxscore(ctx context.Context, cmd GetBoxscoreCommand) (bs BoxscoreData, err error) {
+	ctx, span := tracer.Start(ctx, "nba.GetBoxscore", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("nba.league", c.leagues.Get(cmd.LeagueID).Name),
+		attribute.String("nba.game_id", cmd.GameID),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	return c.getBoxscore(ctx, cmd)
+}
+
+func (c *Client) getBoxscore(ctx context.Context, cmd GetBoxscoreCommand) (BoxscoreData, error) {
+	b, err := c.fetchBoxscore(ctx, cmd, true)
+	if errors.Is(err, errNotCached) {
+		// The document was evicted while the CDN was answering, so it is
+		// asked for in full.
+		return c.fetchBoxscore(ctx, cmd, false)
 	}
 
+	return b, err
+}
+
+// fetchBoxscore requests the boxscore, with the validators of the last one
+// when conditional
+func (c *Client) fetchBoxscore(ctx context.Context, cmd GetBoxscoreCommand, conditional bool) (BoxscoreData, error) {
 	req, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodGet,
-		fmt.Sprintf("%s/static/json/liveData/boxscore/boxscore_%s.json", cdnURL, cmd.GameID),
+		fmt.Sprintf("%s/static/json/liveData/boxscore/boxscore_%s.json", c.leagues.Get(cmd.LeagueID).CDNBaseURL, cmd.GameID),
 		nil,
 	)
 	if err != nil {
 		return BoxscoreData{}, err
 	}
 
-	resp, err := c.doRequest(req)
+	if conditional {
+		c.boxscores.apply(req)
+	}
+
+	resp, err := c.doRequest(req, metrics.EndpointBoxscore, cmd.LeagueID)
 	if err != nil {
 		return BoxscoreData{}, fmt.Errorf("failed to request nba api: %w", err)
 	}
 
 	defer resp.Body.Close()
 
+	metrics.CacheLookup(metrics.CacheBoxscoreETag, resp.StatusCode == http.StatusNotModified)
+
+	if resp.StatusCode == http.StatusNotModified {
+		if b, ok := c.boxscores.get(req.URL.String()); ok {
+			return b, nil
+		}
+
+		return BoxscoreData{}, errNotCached
+	}
+
 	var b BoxscoreData
 	if err := json.NewDecoder(resp.Body).Decode(&b); err != nil {
+		metrics.UpstreamFailed(metrics.EndpointBoxscore, c.leagues.Get(cmd.LeagueID).Name, metrics.ReasonDecode)
+		logging.FromContext(ctx).Warnw("failed to decode upstream response",
+			"endpoint", metrics.EndpointBoxscore, "league", c.leagues.Get(cmd.LeagueID).Name, "err", err)
+
 		return BoxscoreData{}, fmt.Errorf("failed to decode response body: %w", err)
 	}
 
+	c.boxscores.put(req.URL.String(), resp.Header, b)
+
 	return b, nil
 }
 
-func (c *Client) doRequest(req *http.Request) (*http.Response, error) {
+// GetSchedule get the schedule of the current season, from the CDN
+func (c *Client) GetSchedule(ctx context.Context, cmd GetScheduleCommand) (sd ScheduleData, err error) {
+	ctx, span := tracer.Start(ctx, "nba.GetSchedule", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("nba.league", c.leagues.Get(cmd.LeagueID).Name),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	return c.getSchedule(ctx, cmd)
+}
+
+func (c *Client) getSchedule(ctx context.Context, cmd GetScheduleCommand) (ScheduleData, error) {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("%s/static/json/staticData/scheduleLeagueV2.json", c.leagues.Get(cmd.LeagueID).CDNBaseURL),
+		nil,
+	)
+	if err != nil {
+		return ScheduleData{}, err
+	}
+
+	resp, err := c.doRequest(req, metrics.EndpointSchedule, cmd.LeagueID)
+	if err != nil {
+		return ScheduleData{}, fmt.Errorf("failed to request nba api: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	var s ScheduleData
+	if err := json.NewDecoder(resp.Body).Decode(&s); err != nil {
+		metrics.UpstreamFailed(metrics.EndpointSchedule, c.leagues.Get(cmd.LeagueID).Name, metrics.ReasonDecode)
+		logging.FromContext(ctx).Warnw("failed to decode upstream response",
+			"endpoint", metrics.EndpointSchedule, "league", c.leagues.Get(cmd.LeagueID).Name, "err", err)
+
+		return ScheduleData{}, fmt.Errorf("failed to decode response body: %w", err)
+	}
+
+	return s, nil
+}
+
+// Ping checks the stats API and CDN of the NBA answer, at the same time. Their
+// answer may be an error, as long as it isn't a server one. Other leagues are
+// left out, so one of them being down doesn't take the API out of rotation.
+func (c *Client) Ping(ctx context.Context) error {
+	var (
+		wg   sync.WaitGroup
+		l    = c.leagues.Get(NBA)
+		urls = []string{l.StatsBaseURL, l.CDNBaseURL}
+		errs = make([]error, len(urls))
+	)
+
+	for i, u := range urls {
+		wg.Add(1)
+
+		go func(i int, u string) {
+			defer wg.Done()
+
+			errs[i] = c.ping(ctx, u)
+		}(i, u)
+	}
+
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+func (c *Client) ping(ctx context.Context, u string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, u, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := c.prepare(req, NBA); err != nil {
+		return err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach %s: %w", req.URL.Host, err)
+	}
+
+	resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("%s answered with status code %d", req.URL.Host, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// prepare sets the headers the upstream expects, and waits for its rate limit
+func (c *Client) prepare(req *http.Request, league LeagueID) error {
 	// Ignore it. Skip it.
-	req.Header.Set("Referer", c.baseURL)
-	req.Header.Set("Origin", c.baseURL)
+	baseURL := c.leagues.Get(league).StatsBaseURL
+	req.Header.Set("Referer", baseURL)
+	req.Header.Set("Origin", baseURL)
 	req.Header.Set("User-Agent", "PostmanRuntime/7.29.2")
 
+	if c.limiter != nil {
+		if err := c.limiter.Wait(req.Context(), req.URL.Host); err != nil {
+			return fmt.Errorf("failed to wait for upstream rate limit: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// doRequest sends the request to the endpoint, recording its latency and
+// failures
+func (c *Client) doRequest(req *http.Request, endpoint string, league LeagueID) (*http.Response, error) {
+	if err := c.prepare(req, league); err != nil {
+		return nil, err
+	}
+
+	// The rate limiter wait is recorded by the limiter itself.
+	start := time.Now()
+	defer func() { metrics.ObserveUpstream(endpoint, c.leagues.Get(league).Name, time.Since(start)) }()
+
+	span := trace.SpanFromContext(req.Context())
+	span.SetAttributes(semconv.HTTPRequestMethodKey.String(req.Method), semconv.URLFull(req.URL.String()))
+
 	resp, err := c.client.Do(req)
 	if err != nil {
+		metrics.UpstreamFailed(endpoint, c.leagues.Get(league).Name, metrics.ReasonRequest)
+		logging.FromContext(req.Context()).Warnw("upstream request failed", "endpoint", endpoint, "league", c.leagues.Get(league).Name, "err", err)
+
 		return nil, fmt.Errorf("failed to do request: %w", err)
 	}
 
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+
+	logging.FromContext(req.Context()).Debugw("upstream response",
+		"endpoint", endpoint,
+		"league", c.leagues.Get(league).Name,
+		"method", req.Method,
+		"url", req.URL.String(),
+		"if_none_match", req.Header.Get("If-None-Match"),
+		"status", resp.StatusCode,
+		"content_length", resp.ContentLength,
+		"etag", resp.Header.Get("ETag"),
+		"cache_control", resp.Header.Get("Cache-Control"),
+		"latency", time.Since(start),
+	)
+
 	if resp.StatusCode >= http.StatusBadRequest {
-		return nil, fmt.Errorf("failed to get scoreboard with status code %d", resp.StatusCode)
+		metrics.UpstreamFailed(endpoint, c.leagues.Get(league).Name, metrics.ReasonStatus)
+		logging.FromContext(req.Context()).Warnw("upstream answered with an error",
+			"endpoint", endpoint, "league", c.leagues.Get(league).Name, "status", resp.StatusCode)
+		resp.Body.Close()
+
+		if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusForbidden {
+			return nil, fmt.Errorf("failed to get %s with status code %d: %w", endpoint, resp.StatusCode, ErrNotFound)
+		}
+
+		return nil, fmt.Errorf("failed to get %s with status code %d", endpoint, resp.StatusCode)
 	}
 
 	return resp, nil
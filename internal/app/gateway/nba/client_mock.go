@@ -22,3 +22,10 @@ func (m *APIMock) GetBoxscore(ctx context.Context, cmd GetBoxscoreCommand) (Boxs
 
 	return args.Get(0).(BoxscoreData), args.Error(1)
 }
+
+// GetSchedule mock
+func (m *APIMock) GetSchedule(ctx context.Context, cmd GetScheduleCommand) (ScheduleData, error) {
+	args := m.Called(ctx, cmd)
+
+	return args.Get(0).(ScheduleData), args.Error(1)
+}
@@ -0,0 +1,229 @@
+package nba_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba/nbatest"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/logging"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type (
+	ClientTestSuite struct {
+		suite.Suite
+
+		now time.Time
+		fs  *nbatest.Server
+		hs  interface{ Close() }
+		url string
+		rt  *statusRecorder
+		c   *nba.Client
+	}
+
+	// statusRecorder keeps the status code of every response
+	statusRecorder struct {
+		mu       sync.Mutex
+		statuses []int
+	}
+)
+
+func (rt *statusRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	rt.statuses = append(rt.statuses, resp.StatusCode)
+
+	return resp, nil
+}
+
+// notModifiedOnce answers the first request with 304, whatever its validators
+type notModifiedOnce struct {
+	next    http.RoundTripper
+	done    bool
+	retried http.Header
+}
+
+func (rt *notModifiedOnce) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !rt.done {
+		rt.done = true
+
+		return &http.Response{StatusCode: http.StatusNotModified, Header: make(http.Header), Body: http.NoBody, Request: req}, nil
+	}
+
+	rt.retried = req.Header
+
+	return rt.next.RoundTrip(req)
+}
+
+func (s *ClientTestSuite) SetupTest() {
+	s.now = time.Date(2022, 10, 18, 23, 30, 0, 0, time.UTC)
+
+	fs, hs := nbatest.NewTestServer(nbatest.WithClock(func() time.Time { return s.now }))
+
+	s.fs, s.hs, s.url = fs, hs, hs.URL
+	s.rt = &statusRecorder{}
+	s.c = nba.New(&http.Client{Transport: s.rt}, nbatest.Leagues(hs.URL))
+}
+
+func (s *ClientTestSuite) TearDownTest() {
+	s.hs.Close()
+}
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(ClientTestSuite))
+}
+
+func (s *ClientTestSuite) TestBoxscoreNotModified() {
+	ctx := context.Background()
+	cmd := nba.GetBoxscoreCommand{GameID: "0022200001", LeagueID: nba.NBA}
+
+	first, err := s.c.GetBoxscore(ctx, cmd)
+	s.Require().NoError(err)
+
+	second, err := s.c.GetBoxscore(ctx, cmd)
+	s.Require().NoError(err)
+
+	s.Equal([]int{http.StatusOK, http.StatusNotModified}, s.rt.statuses)
+	s.Equal(first, second)
+}
+
+func (s *ClientTestSuite) TestBoxscoreNotModifiedNotCached() {
+	// A CDN answering 304 for a document the client doesn't keep, as when it
+	// was evicted meanwhile, is asked again without validators.
+	var (
+		rt = &notModifiedOnce{next: s.rt}
+		c  = nba.New(&http.Client{Transport: rt}, nbatest.Leagues(s.url))
+	)
+
+	b, err := c.GetBoxscore(context.Background(), nba.GetBoxscoreCommand{GameID: "0022200001", LeagueID: nba.NBA})
+	s.Require().NoError(err)
+	s.Equal("0022200001", b.Boxscore.ID)
+	s.Equal([]int{http.StatusOK}, s.rt.statuses)
+	s.Empty(rt.retried.Get("If-None-Match"))
+}
+
+func (s *ClientTestSuite) TestBoxscoreModified() {
+	ctx := context.Background()
+	cmd := nba.GetBoxscoreCommand{GameID: "0022200001", LeagueID: nba.NBA}
+
+	s.fs.StartLive(cmd.GameID, s.now.Add(-time.Hour), 2*time.Hour)
+
+	live, err := s.c.GetBoxscore(ctx, cmd)
+	s.Require().NoError(err)
+	s.Equal(nba.Live, live.Boxscore.Status)
+
+	s.now = s.now.Add(2 * time.Hour)
+
+	final, err := s.c.GetBoxscore(ctx, cmd)
+	s.Require().NoError(err)
+	s.Equal(nba.Final, final.Boxscore.Status)
+
+	s.Equal([]int{http.StatusOK, http.StatusOK}, s.rt.statuses)
+}
+
+func (s *ClientTestSuite) TestSchedule() {
+	sd, err := s.c.GetSchedule(context.Background(), nba.GetScheduleCommand{LeagueID: nba.NBA})
+	s.Require().NoError(err)
+
+	s.Equal("2022-23", sd.Schedule.SeasonYear)
+	s.Require().Len(sd.Schedule.GameDates, 3)
+
+	g := sd.Schedule.GameDates[1].Games[0]
+	s.Equal("0022200001", g.ID)
+	s.Equal(nba.Final, g.Status)
+	s.Equal(nba.GameTime(time.Date(2022, 10, 18, 23, 30, 0, 0, time.UTC)), g.StartsAt)
+	s.Equal(nba.ScheduledTeam{ID: 1610612738, Name: "Celtics", Tricode: "BOS", Score: 124}, g.HomeTeam)
+}
+
+func (s *ClientTestSuite) TestSpans() {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	otel.SetTracerProvider(tp)
+
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	_, err := s.c.GetBoxscore(context.Background(), nba.GetBoxscoreCommand{GameID: "0022200001", LeagueID: nba.NBA})
+	s.Require().NoError(err)
+
+	_, err = s.c.GetBoxscore(context.Background(), nba.GetBoxscoreCommand{GameID: "0022200404", LeagueID: nba.WNBA})
+	s.Require().ErrorContains(err, "failed to get boxscore with status code 403")
+	s.ErrorIs(err, nba.ErrNotFound)
+
+	spans := sr.Ended()
+	s.Require().Len(spans, 2)
+
+	for i, exp := range []struct {
+		gameID, league string
+		status         int64
+		code           codes.Code
+	}{
+		{"0022200001", "nba", http.StatusOK, codes.Unset},
+		{"0022200404", "wnba", http.StatusForbidden, codes.Error},
+	} {
+		attrs := attribute.NewSet(spans[i].Attributes()...)
+
+		s.Equal("nba.GetBoxscore", spans[i].Name())
+		s.Equal(exp.code, spans[i].Status().Code)
+
+		v, _ := attrs.Value("nba.game_id")
+		s.Equal(exp.gameID, v.AsString())
+		v, _ = attrs.Value("nba.league")
+		s.Equal(exp.league, v.AsString())
+		v, _ = attrs.Value("http.response.status_code")
+		s.Equal(exp.status, v.AsInt64())
+	}
+}
+
+func (s *ClientTestSuite) TestDebugLogs() {
+	core, logs := observer.New(zapcore.DebugLevel)
+	ctx := logging.WithLogger(context.Background(), zap.New(core).Sugar())
+	cmd := nba.GetBoxscoreCommand{GameID: "0022200001", LeagueID: nba.NBA}
+
+	_, err := s.c.GetBoxscore(ctx, cmd)
+	s.Require().NoError(err)
+
+	_, err = s.c.GetBoxscore(ctx, cmd)
+	s.Require().NoError(err)
+
+	entries := logs.FilterMessage("upstream response").All()
+	s.Require().Len(entries, 2)
+
+	first, second := entries[0].ContextMap(), entries[1].ContextMap()
+	s.Equal("boxscore", first["endpoint"])
+	s.Equal("nba", first["league"])
+	s.Equal(http.MethodGet, first["method"])
+	s.EqualValues(http.StatusOK, first["status"])
+	s.NotEmpty(first["etag"])
+	s.Empty(first["if_none_match"])
+
+	s.EqualValues(http.StatusNotModified, second["status"])
+	s.Equal(first["etag"], second["if_none_match"])
+}
+
+func (s *ClientTestSuite) TestPing() {
+	s.NoError(s.c.Ping(context.Background()))
+
+	s.hs.Close()
+	s.Error(s.c.Ping(context.Background()))
+}
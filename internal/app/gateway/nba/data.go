@@ -12,12 +12,17 @@ const (
 	gameHourFormat          = "15:04 UTC"
 	NBA            LeagueID = "00"
 	WNBA           LeagueID = "10"
+
+	Scheduled GameStatus = 1
+	Live      GameStatus = 2
+	Final     GameStatus = 3
 )
 
 type (
-	LeagueID string
-	GameDate time.Time
-	GameTime time.Time
+	LeagueID   string
+	GameStatus int
+	GameDate   time.Time
+	GameTime   time.Time
 
 	GetScoreboardCommand struct {
 		Date     string
@@ -25,7 +30,11 @@ type (
 	}
 
 	GetBoxscoreCommand struct {
-		GameID string
+		GameID   string
+		LeagueID LeagueID
+	}
+
+	GetScheduleCommand struct {
 		LeagueID LeagueID
 	}
 
@@ -39,10 +48,11 @@ type (
 	}
 
 	Game struct {
-		ID       string   `json:"gameId"`
-		StartsAt GameTime `json:"gameTimeUTC"`
-		HomeTeam Team     `json:"homeTeam"`
-		AwayTeam Team     `json:"awayTeam"`
+		ID       string     `json:"gameId"`
+		Status   GameStatus `json:"gameStatus"`
+		StartsAt GameTime   `json:"gameTimeUTC"`
+		HomeTeam Team       `json:"homeTeam"`
+		AwayTeam Team       `json:"awayTeam"`
 	}
 
 	Team struct {
@@ -58,9 +68,41 @@ type (
 	}
 
 	Boxscore struct {
-		ID       string `json:"gameId"`
-		HomeTeam Team   `json:"homeTeam"`
-		AwayTeam Team   `json:"awayTeam"`
+		ID       string     `json:"gameId"`
+		Status   GameStatus `json:"gameStatus"`
+		HomeTeam Team       `json:"homeTeam"`
+		AwayTeam Team       `json:"awayTeam"`
+	}
+
+	// ScheduleData is the schedule of the current season, from preseason to
+	// the finals
+	ScheduleData struct {
+		Schedule Schedule `json:"leagueSchedule"`
+	}
+
+	Schedule struct {
+		// SeasonYear is like 2022-23, or 2023 for the WNBA
+		SeasonYear string         `json:"seasonYear"`
+		GameDates  []ScheduleDate `json:"gameDates"`
+	}
+
+	ScheduleDate struct {
+		Games []ScheduledGame `json:"games"`
+	}
+
+	ScheduledGame struct {
+		ID       string        `json:"gameId"`
+		Status   GameStatus    `json:"gameStatus"`
+		StartsAt GameTime      `json:"gameDateTimeUTC"`
+		HomeTeam ScheduledTeam `json:"homeTeam"`
+		AwayTeam ScheduledTeam `json:"awayTeam"`
+	}
+
+	ScheduledTeam struct {
+		ID      int64  `json:"teamId"`
+		Name    string `json:"teamName"`
+		Tricode string `json:"teamTricode"`
+		Score   int64  `json:"score"`
 	}
 
 	Player struct {
@@ -97,6 +139,69 @@ type (
 	}
 )
 
+// IsFinal reports whether every game in the scoreboard is over.
+func (s Scoreboard) IsFinal() bool {
+	if len(s.Games) == 0 {
+		return false
+	}
+
+	for _, g := range s.Games {
+		if g.Status != Final {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsFinal reports whether the game is over.
+func (b Boxscore) IsFinal() bool {
+	return b.Status == Final
+}
+
+func (gs GameStatus) String() string {
+	switch gs {
+	case Scheduled:
+		return "scheduled"
+	case Live:
+		return "live"
+	case Final:
+		return "final"
+	default:
+		return "unknown"
+	}
+}
+
+func (gs GameStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(gs.String())
+}
+
+// UnmarshalJSON reads the numeric status of the upstream and the names the
+// API answers with, so its responses decode back
+func (gs *GameStatus) UnmarshalJSON(data []byte) error {
+	var code int
+	if err := json.Unmarshal(data, &code); err == nil {
+		*gs = GameStatus(code)
+
+		return nil
+	}
+
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return fmt.Errorf("invalid game status %s", data)
+	}
+
+	for _, s := range []GameStatus{Scheduled, Live, Final} {
+		if s.String() == name {
+			*gs = s
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown game status %q", name)
+}
+
 func (gd GameDate) String() string {
 	return time.Time(gd).String()
 }
@@ -127,6 +232,14 @@ func (gd *GameDate) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (gd GameDate) MarshalBinary() ([]byte, error) {
+	return time.Time(gd).MarshalBinary()
+}
+
+func (gd *GameDate) UnmarshalBinary(data []byte) error {
+	return (*time.Time)(gd).UnmarshalBinary(data)
+}
+
 func (gt GameTime) String() string {
 	return time.Time(gt).String()
 }
@@ -157,13 +270,10 @@ func (gt *GameTime) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-func ParseLeague(l string) LeagueID {
-	switch l {
-	case "wnba":
-		return WNBA
-	case "nba":
-		fallthrough
-	default:
-		return NBA
-	}
+func (gt GameTime) MarshalBinary() ([]byte, error) {
+	return time.Time(gt).MarshalBinary()
+}
+
+func (gt *GameTime) UnmarshalBinary(data []byte) error {
+	return (*time.Time)(gt).UnmarshalBinary(data)
 }
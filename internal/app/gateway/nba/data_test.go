@@ -0,0 +1,55 @@
+package nba_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameStatusJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		data string
+
+		expStatus nba.GameStatus
+		expErr    string
+	}{
+		{data: `3`, expStatus: nba.Final},
+		{data: `1`, expStatus: nba.Scheduled},
+		{data: `"live"`, expStatus: nba.Live},
+		{data: `"final"`, expStatus: nba.Final},
+		{data: `"postponed"`, expErr: `unknown game status "postponed"`},
+		{data: `true`, expErr: "invalid game status true"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.data, func(t *testing.T) {
+			t.Parallel()
+
+			var gs nba.GameStatus
+
+			err := json.Unmarshal([]byte(tt.data), &gs)
+			if tt.expErr != "" {
+				assert.ErrorContains(t, err, tt.expErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expStatus, gs)
+
+			data, err := json.Marshal(gs)
+			require.NoError(t, err)
+
+			var back nba.GameStatus
+			require.NoError(t, json.Unmarshal(data, &back))
+			assert.Equal(t, gs, back)
+		})
+	}
+}
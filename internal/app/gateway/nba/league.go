@@ -0,0 +1,270 @@
+package nba
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	// League timezones are looked up by name, whatever the image running the API has.
+	_ "time/tzdata"
+)
+
+const (
+	GLeague      LeagueID = "20"
+	SummerLeague LeagueID = "15"
+)
+
+type (
+	// League is a league of the NBA family, served by the same upstream APIs
+	League struct {
+		ID LeagueID `json:"id"`
+		// Name is how the league is asked for, e.g. ?league=gleague, and its
+		// label in metrics and logs
+		Name         string `json:"name"`
+		DisplayName  string `json:"display_name"`
+		StatsBaseURL string `json:"stats_base_url"`
+		CDNBaseURL   string `json:"cdn_base_url"`
+		// Timezone is where the game days of the league are
+		Timezone string `json:"timezone"`
+		// GameIDPrefix starts the id of every game of the league
+		GameIDPrefix string `json:"game_id_prefix"`
+		Assets       Assets `json:"assets"`
+
+		location *time.Location
+	}
+
+	// Assets are URL templates of images, with {team_id} or {player_id} in
+	// them
+	Assets struct {
+		TeamLogo       string `json:"team_logo"`
+		PlayerHeadshot string `json:"player_headshot"`
+	}
+
+	// Registry are the leagues the API serves. The first one is the default,
+	// used for unknown leagues.
+	Registry struct {
+		leagues []League
+		byID    map[LeagueID]League
+		byName  map[string]League
+	}
+)
+
+// DefaultLeagues are the leagues known without a leagues file. They share the
+// stats API, the G League and Summer League the NBA CDN too.
+func DefaultLeagues(statsBaseURL, cdnBaseURL, wnbaCDNBaseURL string) []League {
+	nbaAssets := Assets{
+		TeamLogo:       "https://cdn.nba.com/logos/nba/{team_id}/global/L/logo.svg",
+		PlayerHeadshot: "https://cdn.nba.com/headshots/nba/latest/1040x760/{player_id}.png",
+	}
+
+	return []League{
+		{
+			ID: NBA, Name: "nba", DisplayName: "NBA",
+			StatsBaseURL: statsBaseURL, CDNBaseURL: cdnBaseURL,
+			Timezone: "America/New_York", GameIDPrefix: "00", Assets: nbaAssets,
+		},
+		{
+			ID: WNBA, Name: "wnba", DisplayName: "WNBA",
+			StatsBaseURL: statsBaseURL, CDNBaseURL: wnbaCDNBaseURL,
+			Timezone: "America/New_York", GameIDPrefix: "10",
+			Assets: Assets{
+				TeamLogo:       "https://cdn.wnba.com/logos/wnba/{team_id}/primary/L/logo.svg",
+				PlayerHeadshot: "https://cdn.wnba.com/headshots/wnba/latest/1040x760/{player_id}.png",
+			},
+		},
+		{
+			ID: GLeague, Name: "gleague", DisplayName: "NBA G League",
+			StatsBaseURL: statsBaseURL, CDNBaseURL: cdnBaseURL,
+			Timezone: "America/New_York", GameIDPrefix: "20",
+			Assets: Assets{
+				TeamLogo:       "https://ak-static.cms.nba.com/wp-content/uploads/logos/nbagleague/{team_id}/primary/L/logo.svg",
+				PlayerHeadshot: nbaAssets.PlayerHeadshot,
+			},
+		},
+		{
+			ID: SummerLeague, Name: "summer", DisplayName: "NBA Summer League",
+			StatsBaseURL: statsBaseURL, CDNBaseURL: cdnBaseURL,
+			Timezone: "America/Los_Angeles", GameIDPrefix: "15", Assets: nbaAssets,
+		},
+	}
+}
+
+// LoadLeagues reads a JSON list of leagues and adds them to base. Leagues in
+// the file replace the ones with the same id, keeping their place.
+func LoadLeagues(path string, base []League) ([]League, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read leagues: %w", err)
+	}
+
+	var file []League
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to decode leagues %s: %w", path, err)
+	}
+
+	ll := append([]League{}, base...)
+
+next:
+	for _, l := range file {
+		for i := range ll {
+			if ll[i].ID == l.ID {
+				ll[i] = l
+
+				continue next
+			}
+		}
+
+		ll = append(ll, l)
+	}
+
+	return ll, nil
+}
+
+// NewRegistry checks the leagues have unique ids and names, http(s) base URLs
+// and a known timezone
+func NewRegistry(ll []League) (*Registry, error) {
+	if len(ll) == 0 {
+		return nil, errors.New("leagues: at least one is needed")
+	}
+
+	var (
+		errs  []error
+		ids   = make(map[LeagueID]bool, len(ll))
+		names = make(map[string]bool, len(ll))
+	)
+
+	for _, l := range ll {
+		if err := l.validate(); err != nil {
+			errs = append(errs, err)
+		}
+
+		if ids[l.ID] {
+			errs = append(errs, fmt.Errorf("leagues: duplicate id %q", l.ID))
+		}
+
+		if names[l.Name] {
+			errs = append(errs, fmt.Errorf("leagues: duplicate name %q", l.Name))
+		}
+
+		ids[l.ID], names[l.Name] = true, true
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return newRegistry(ll), nil
+}
+
+// newRegistry indexes the leagues without checking them
+func newRegistry(ll []League) *Registry {
+	r := &Registry{
+		leagues: make([]League, len(ll)),
+		byID:    make(map[LeagueID]League, len(ll)),
+		byName:  make(map[string]League, len(ll)),
+	}
+
+	for i, l := range ll {
+		l.location, _ = time.LoadLocation(l.Timezone)
+
+		r.leagues[i] = l
+		r.byID[l.ID] = l
+		r.byName[l.Name] = l
+	}
+
+	return r
+}
+
+func (l League) validate() error {
+	if l.ID == "" || l.Name == "" {
+		return fmt.Errorf("leagues: %q needs an id and a name", l.DisplayName)
+	}
+
+	var errs []error
+
+	if l.Name != strings.ToLower(l.Name) {
+		errs = append(errs, fmt.Errorf("leagues: name %q must be lowercase", l.Name))
+	}
+
+	for _, u := range []struct{ name, url string }{
+		{"stats_base_url", l.StatsBaseURL},
+		{"cdn_base_url", l.CDNBaseURL},
+	} {
+		if u.url == "" {
+			errs = append(errs, fmt.Errorf("leagues: %s needs a %s", l.Name, u.name))
+
+			continue
+		}
+
+		if parsed, err := url.Parse(u.url); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			errs = append(errs, fmt.Errorf("leagues: %s %s %q is not an http(s) url", l.Name, u.name, u.url))
+		}
+	}
+
+	// Local is the timezone of the server, not of a league.
+	if _, err := time.LoadLocation(l.Timezone); err != nil || l.Timezone == "" || l.Timezone == "Local" {
+		errs = append(errs, fmt.Errorf("leagues: %s has an unknown timezone %q", l.Name, l.Timezone))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Get is the league with the id, or the default one
+func (r *Registry) Get(id LeagueID) League {
+	if l, ok := r.byID[id]; ok {
+		return l
+	}
+
+	return r.leagues[0]
+}
+
+// Default is the league served when none is asked for, the first one
+func (r *Registry) Default() League { return r.leagues[0] }
+
+// ByName is the league with the name, or the default one
+func (r *Registry) ByName(name string) League {
+	if l, ok := r.byName[name]; ok {
+		return l
+	}
+
+	return r.leagues[0]
+}
+
+// ByGameID is the league the game belongs to, by the prefix of its id
+func (r *Registry) ByGameID(gameID string) (League, bool) {
+	for _, l := range r.leagues {
+		if l.GameIDPrefix != "" && strings.HasPrefix(gameID, l.GameIDPrefix) {
+			return l, true
+		}
+	}
+
+	return League{}, false
+}
+
+// All are the leagues, the default first
+func (r *Registry) All() []League { return append([]League{}, r.leagues...) }
+
+// Location is where the game days of the league are
+func (l League) Location() *time.Location {
+	if l.location == nil {
+		return time.UTC
+	}
+
+	return l.location
+}
+
+// TeamLogoURL is the logo of a team of the league, empty without a template
+func (l League) TeamLogoURL(teamID int64) string {
+	return strings.ReplaceAll(l.Assets.TeamLogo, "{team_id}", strconv.FormatInt(teamID, 10))
+}
+
+// PlayerHeadshotURL is the headshot of a player of the league, empty without
+// a template
+func (l League) PlayerHeadshotURL(playerID int64) string {
+	return strings.ReplaceAll(l.Assets.PlayerHeadshot, "{player_id}", strconv.FormatInt(playerID, 10))
+}
@@ -0,0 +1,174 @@
+package nba_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultLeagues(t *testing.T) {
+	t.Parallel()
+
+	r, err := nba.NewRegistry(nba.DefaultLeagues("https://stats.nba.com", "https://cdn.nba.com", "https://cdn.wnba.com"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+
+		expID   nba.LeagueID
+		expName string
+	}{
+		{name: "nba", expID: nba.NBA, expName: "nba"},
+		{name: "wnba", expID: nba.WNBA, expName: "wnba"},
+		{name: "gleague", expID: nba.GLeague, expName: "gleague"},
+		{name: "summer", expID: nba.SummerLeague, expName: "summer"},
+		{name: "euroleague", expID: nba.NBA, expName: "nba"},
+		{name: "", expID: nba.NBA, expName: "nba"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := r.ByName(tt.name)
+			assert.Equal(t, tt.expID, l.ID)
+			assert.Equal(t, tt.expName, r.Get(l.ID).Name)
+		})
+	}
+
+	assert.Equal(t, "America/Los_Angeles", r.Get(nba.SummerLeague).Location().String())
+	assert.Equal(t, "https://cdn.nba.com/logos/nba/1610612738/global/L/logo.svg", r.Get(nba.NBA).TeamLogoURL(1610612738))
+	assert.Equal(t, "https://cdn.wnba.com/headshots/wnba/latest/1040x760/1628932.png", r.Get(nba.WNBA).PlayerHeadshotURL(1628932))
+
+	l, ok := r.ByGameID("2022300001")
+	assert.True(t, ok)
+	assert.Equal(t, nba.GLeague, l.ID)
+
+	_, ok = r.ByGameID("9922300001")
+	assert.False(t, ok)
+}
+
+func TestNewRegistryInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := nba.NewRegistry(nil)
+	assert.Error(t, err)
+
+	_, err = nba.NewRegistry([]nba.League{
+		{ID: "00", Name: "NBA", StatsBaseURL: "https://stats.nba.com", CDNBaseURL: "cdn.nba.com", Timezone: "America/New_York"},
+		{ID: "00", Name: "NBA", StatsBaseURL: "https://stats.nba.com", CDNBaseURL: "https://cdn.nba.com", Timezone: "Local"},
+		{ID: "91", Name: "euroleague", StatsBaseURL: "https://stats.euroleague.test", Timezone: "Europe/Madrid"},
+		{DisplayName: "Nameless"},
+	})
+
+	assert.ErrorContains(t, err, `name "NBA" must be lowercase`)
+	assert.ErrorContains(t, err, `NBA cdn_base_url "cdn.nba.com" is not an http(s) url`)
+	assert.ErrorContains(t, err, `duplicate id "00"`)
+	assert.ErrorContains(t, err, `duplicate name "NBA"`)
+	assert.ErrorContains(t, err, `NBA has an unknown timezone "Local"`)
+	assert.ErrorContains(t, err, `euroleague needs a cdn_base_url`)
+	assert.ErrorContains(t, err, `"Nameless" needs an id and a name`)
+}
+
+func TestLoadLeagues(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "leagues.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[
+		{"id": "20", "name": "gleague", "stats_base_url": "https://stats.gleague.nba.com", "cdn_base_url": "https://cdn.nba.com", "timezone": "America/Chicago"},
+		{"id": "90", "name": "ncaa", "stats_base_url": "https://stats.ncaa.test", "cdn_base_url": "https://cdn.ncaa.test", "timezone": "America/New_York", "game_id_prefix": "90"},
+		{"id": "91", "name": "euroleague", "stats_base_url": "https://stats.euroleague.test", "cdn_base_url": "https://cdn.euroleague.test", "timezone": "Europe/Madrid"}
+	]`), 0o600))
+
+	ll, err := nba.LoadLeagues(path, nba.DefaultLeagues("https://stats.nba.com", "https://cdn.nba.com", "https://cdn.wnba.com"))
+	require.NoError(t, err)
+
+	r, err := nba.NewRegistry(ll)
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(ll))
+	for _, l := range r.All() {
+		names = append(names, l.Name)
+	}
+
+	assert.Equal(t, []string{"nba", "wnba", "gleague", "summer", "ncaa", "euroleague"}, names)
+	assert.Equal(t, "https://stats.gleague.nba.com", r.Get(nba.GLeague).StatsBaseURL)
+	assert.Equal(t, "America/Chicago", r.Get(nba.GLeague).Location().String())
+	assert.Equal(t, "ncaa", r.ByName("ncaa").Name)
+
+	_, err = nba.LoadLeagues(filepath.Join(t.TempDir(), "missing.json"), nil)
+	assert.Error(t, err)
+}
+
+func TestClientLeagues(t *testing.T) {
+	t.Parallel()
+
+	var paths []string
+
+	hs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Host+r.URL.Path)
+		http.NotFound(w, r)
+	}))
+	defer hs.Close()
+
+	ll := nba.DefaultLeagues("https://stats.nba.test", "https://cdn.nba.test", "https://cdn.wnba.test")
+	ll[2].StatsBaseURL, ll[2].CDNBaseURL = hs.URL, hs.URL
+
+	r, err := nba.NewRegistry(ll)
+	require.NoError(t, err)
+
+	c := nba.New(http.DefaultClient, r)
+
+	_, err = c.GetBoxscore(context.Background(), nba.GetBoxscoreCommand{GameID: "2022300001", LeagueID: nba.GLeague})
+	assert.Error(t, err)
+
+	_, err = c.GetScoreboard(context.Background(), nba.GetScoreboardCommand{Date: "2022-11-04", LeagueID: nba.GLeague})
+	assert.Error(t, err)
+
+	host := hs.Listener.Addr().String()
+	assert.Equal(t, []string{host + "/static/json/liveData/boxscore/boxscore_2022300001.json", host + "/stats/scoreboardv3"}, paths)
+}
+
+func TestPingLeagues(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu     sync.Mutex
+		agents []string
+	)
+
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		agents = append(agents, r.Header.Get("User-Agent"))
+	}))
+	defer up.Close()
+
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer down.Close()
+
+	ll := nba.DefaultLeagues(up.URL, up.URL, up.URL)
+	ll[2].StatsBaseURL, ll[2].CDNBaseURL = down.URL, down.URL
+
+	r, err := nba.NewRegistry(ll)
+	require.NoError(t, err)
+
+	c := nba.New(http.DefaultClient, r)
+
+	// Only the NBA is probed, with the headers of every other request.
+	require.NoError(t, c.Ping(context.Background()))
+	assert.Equal(t, []string{"PostmanRuntime/7.29.2", "PostmanRuntime/7.29.2"}, agents)
+}
@@ -0,0 +1,457 @@
+// Package nbatest provides a fake of the stats.nba.com and liveData CDN
+// endpoints used by nba.Client. It serves recorded fixtures, can simulate a
+// game progressing live and can inject upstream failures.
+package nbatest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+)
+
+const (
+	scoreboardPath = "/stats/scoreboardv3"
+	boxscorePath   = "/static/json/liveData/boxscore/"
+	schedulePath   = "/static/json/staticData/scheduleLeagueV2.json"
+	faultsPath     = "/_fake/faults"
+	livePath       = "/_fake/live"
+)
+
+//go:embed fixtures
+var fixtures embed.FS
+
+type (
+	// Faults configures the failures injected in every upstream response.
+	// Rates are probabilities between 0 and 1.
+	Faults struct {
+		Latency       time.Duration `json:"latency"`
+		ForbiddenRate float64       `json:"forbidden_rate"`
+		ThrottledRate float64       `json:"throttled_rate"`
+		MalformedRate float64       `json:"malformed_rate"`
+	}
+
+	// LiveGame is a game whose fixture is replayed as if it was being played
+	LiveGame struct {
+		StartedAt time.Time
+		Duration  time.Duration
+	}
+
+	// Server fakes the NBA upstreams. Both NBA_BASE_URL and NBA_CDN_BASE_URL
+	// can point at it.
+	Server struct {
+		fixtures fs.FS
+		now      func() time.Time
+		mux      *http.ServeMux
+
+		mu     sync.RWMutex
+		faults Faults
+		live   map[string]LiveGame
+	}
+
+	// Option configures the Server
+	Option func(*Server)
+)
+
+// WithFixtures replaces the embedded fixtures. The file system must follow the
+// same layout: scoreboardv3/<league id>/<date>.json, boxscore/<game id>.json and
+// schedule.json.
+func WithFixtures(f fs.FS) Option {
+	return func(s *Server) { s.fixtures = f }
+}
+
+// WithFaults sets the initial faults
+func WithFaults(f Faults) Option {
+	return func(s *Server) { s.faults = f }
+}
+
+// WithClock replaces time.Now, mostly to drive live games from tests
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) { s.now = now }
+}
+
+// New creates a new fake upstream
+func New(opts ...Option) *Server {
+	sub, _ := fs.Sub(fixtures, "fixtures")
+
+	s := &Server{
+		fixtures: sub,
+		now:      time.Now,
+		live:     make(map[string]LiveGame),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.mux = http.NewServeMux()
+	s.mux.HandleFunc(scoreboardPath, s.withFaults(s.getScoreboard))
+	s.mux.HandleFunc(boxscorePath, s.withFaults(s.getBoxscore))
+	s.mux.HandleFunc(schedulePath, s.withFaults(s.getSchedule))
+	s.mux.HandleFunc(faultsPath, s.handleFaults)
+	s.mux.HandleFunc(livePath, s.handleLive)
+
+	return s
+}
+
+// NewTestServer starts a fake upstream on a local port. Callers must close it.
+func NewTestServer(opts ...Option) (*Server, *httptest.Server) {
+	s := New(opts...)
+
+	return s, httptest.NewServer(s)
+}
+
+// Leagues are the default leagues, every one served by the fake upstream at
+// url
+func Leagues(url string) *nba.Registry {
+	r, err := nba.NewRegistry(nba.DefaultLeagues(url, url, url))
+	if err != nil {
+		panic(err)
+	}
+
+	return r
+}
+
+// SetFaults replaces the injected faults
+func (s *Server) SetFaults(f Faults) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = f
+}
+
+// StartLive replays the fixture of the game as live, from startedAt until the
+// duration elapses, when it becomes final.
+func (s *Server) StartLive(gameID string, startedAt time.Time, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.live[gameID] = LiveGame{StartedAt: startedAt, Duration: d}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) getScoreboard(w http.ResponseWriter, r *http.Request) {
+	var (
+		league = r.URL.Query().Get("LeagueID")
+		date   = r.URL.Query().Get("GameDate")
+	)
+
+	if date == "" {
+		date = s.now().UTC().Format("2006-01-02")
+	}
+
+	doc, err := s.readFixture(fmt.Sprintf("scoreboardv3/%s/%s.json", league, date))
+	if errors.Is(err, fs.ErrNotExist) {
+		// Days without games still have a scoreboard.
+		doc = map[string]any{
+			"scoreboard": map[string]any{"gameDate": date, "leagueId": league, "games": []any{}},
+		}
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	if sb, ok := doc["scoreboard"].(map[string]any); ok {
+		games, _ := sb["games"].([]any)
+		for _, g := range games {
+			if g, ok := g.(map[string]any); ok {
+				s.simulate(g)
+			}
+		}
+	}
+
+	writeJSON(w, doc)
+}
+
+func (s *Server) getBoxscore(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, boxscorePath)
+	if !strings.HasPrefix(name, "boxscore_") || !strings.HasSuffix(name, ".json") {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	gameID := strings.TrimSuffix(strings.TrimPrefix(name, "boxscore_"), ".json")
+
+	doc, err := s.readFixture(fmt.Sprintf("boxscore/%s.json", gameID))
+	if errors.Is(err, fs.ErrNotExist) {
+		// The CDN answers unknown files with access denied.
+		http.Error(w, "AccessDenied", http.StatusForbidden)
+
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	if g, ok := doc["game"].(map[string]any); ok {
+		s.simulate(g)
+	}
+
+	// Like the CDN, boxscores carry an ETag and unchanged ones are answered with 304.
+	var buf bytes.Buffer
+	json.NewEncoder(&buf).Encode(doc) //nolint: errcheck
+
+	sum := sha256.Sum256(buf.Bytes())
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+
+	w.Header().Set("ETag", etag)
+
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(buf.Bytes()) //nolint: errcheck
+}
+
+// getSchedule serves the season of the fixtures, whatever the league CDN
+func (s *Server) getSchedule(w http.ResponseWriter, r *http.Request) {
+	doc, err := s.readFixture("schedule.json")
+	if errors.Is(err, fs.ErrNotExist) {
+		http.Error(w, "AccessDenied", http.StatusForbidden)
+
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	if ls, ok := doc["leagueSchedule"].(map[string]any); ok {
+		dates, _ := ls["gameDates"].([]any)
+		for _, d := range dates {
+			d, _ := d.(map[string]any)
+			games, _ := d["games"].([]any)
+
+			for _, g := range games {
+				if g, ok := g.(map[string]any); ok {
+					s.simulate(g)
+				}
+			}
+		}
+	}
+
+	writeJSON(w, doc)
+}
+
+// withFaults delays and fails requests according to the configured faults
+func (s *Server) withFaults(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.RLock()
+		f := s.faults
+		s.mu.RUnlock()
+
+		if f.Latency > 0 {
+			select {
+			case <-time.After(f.Latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		switch {
+		case roll(f.ForbiddenRate):
+			http.Error(w, "Forbidden", http.StatusForbidden)
+		case roll(f.ThrottledRate):
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+		case roll(f.MalformedRate):
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"scoreboard":{"gameDate":"2022-10-18","games":[{"gameId":`)) //nolint: errcheck
+		default:
+			next(w, r)
+		}
+	}
+}
+
+func (s *Server) handleFaults(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+
+		writeJSON(w, s.faults)
+	case http.MethodPut, http.MethodPost:
+		var f Faults
+		if err := json.NewDecoder(r.Body).Decode(&f); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		s.SetFaults(f)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleLive(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	var req struct {
+		GameID   string `json:"game_id"`
+		Duration string `json:"duration"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	d, err := time.ParseDuration(req.Duration)
+	if err != nil || req.GameID == "" {
+		http.Error(w, "game_id and duration are required", http.StatusBadRequest)
+
+		return
+	}
+
+	s.StartLive(req.GameID, s.now(), d)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) readFixture(name string) (map[string]any, error) {
+	data, err := fs.ReadFile(s.fixtures, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid fixture %s: %w", name, err)
+	}
+
+	return doc, nil
+}
+
+// simulate rewrites a game, from a scoreboard or a boxscore, to how it looked
+// at this point of its live replay.
+func (s *Server) simulate(game map[string]any) {
+	id, _ := game["gameId"].(string)
+
+	s.mu.RLock()
+	lg, ok := s.live[id]
+	s.mu.RUnlock()
+
+	if !ok {
+		return
+	}
+
+	progress := lg.progress(s.now())
+	if progress >= 1 {
+		return
+	}
+
+	game["gameStatus"], game["gameStatusText"], game["period"] = 2, "Live", int(progress*4)+1
+	if progress == 0 {
+		game["gameStatus"], game["gameStatusText"], game["period"] = 1, "Scheduled", 0
+	}
+
+	for _, side := range []string{"homeTeam", "awayTeam"} {
+		t, ok := game[side].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if score, ok := t["score"].(float64); ok {
+			t["score"] = scale(score, progress)
+		}
+
+		scaleStats(t["statistics"], progress)
+
+		players, _ := t["players"].([]any)
+		for _, p := range players {
+			if p, ok := p.(map[string]any); ok {
+				scaleStats(p["statistics"], progress)
+			}
+		}
+	}
+}
+
+func (lg LiveGame) progress(now time.Time) float64 {
+	if lg.Duration <= 0 {
+		return 1
+	}
+
+	p := float64(now.Sub(lg.StartedAt)) / float64(lg.Duration)
+
+	switch {
+	case p < 0:
+		return 0
+	case p > 1:
+		return 1
+	default:
+		return p
+	}
+}
+
+// scaleStats shrinks the final stat line proportionally to the game progress
+func scaleStats(v any, progress float64) {
+	st, ok := v.(map[string]any)
+	if !ok {
+		return
+	}
+
+	for k, v := range st {
+		switch v := v.(type) {
+		case float64:
+			if !strings.HasSuffix(k, "Percentage") {
+				st[k] = scale(v, progress)
+			}
+		case string:
+			if k == "minutes" {
+				st[k] = scaleMinutes(v, progress)
+			}
+		}
+	}
+}
+
+func scale(v, progress float64) float64 {
+	return float64(int64(v * progress))
+}
+
+// scaleMinutes scales durations in the PT25M12.02S format
+func scaleMinutes(v string, progress float64) string {
+	var m, sec, frac int
+	if _, err := fmt.Sscanf(v, "PT%dM%d.%dS", &m, &sec, &frac); err != nil {
+		return v
+	}
+
+	total := int(float64(m*60+sec) * progress)
+
+	return fmt.Sprintf("PT%02dM%02d.00S", total/60, total%60)
+}
+
+func roll(rate float64) bool {
+	return rate > 0 && rand.Float64() < rate //nolint: gosec
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v) //nolint: errcheck
+}
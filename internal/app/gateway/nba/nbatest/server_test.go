@@ -0,0 +1,123 @@
+package nbatest_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba/nbatest"
+	"github.com/stretchr/testify/suite"
+)
+
+type ServerTestSuite struct {
+	suite.Suite
+
+	now time.Time
+	fs  *nbatest.Server
+	hs  interface{ Close() }
+	c   *nba.Client
+}
+
+func (s *ServerTestSuite) SetupTest() {
+	s.now = time.Date(2022, 10, 18, 23, 30, 0, 0, time.UTC)
+
+	fs, hs := nbatest.NewTestServer(nbatest.WithClock(func() time.Time { return s.now }))
+
+	s.fs, s.hs = fs, hs
+	s.c = nba.New(http.DefaultClient, nbatest.Leagues(hs.URL))
+}
+
+func (s *ServerTestSuite) TearDownTest() {
+	s.hs.Close()
+}
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(ServerTestSuite))
+}
+
+func (s *ServerTestSuite) TestScoreboard() {
+	ctx := context.Background()
+
+	sb, err := s.c.GetScoreboard(ctx, nba.GetScoreboardCommand{Date: "2022-10-18", LeagueID: nba.NBA})
+	s.Require().NoError(err)
+	s.Len(sb.Scoreboard.Games, 2)
+	s.True(sb.Scoreboard.IsFinal())
+	s.Equal("BOS", sb.Scoreboard.Games[0].HomeTeam.Tricode)
+
+	sb, err = s.c.GetScoreboard(ctx, nba.GetScoreboardCommand{Date: "2022-10-17", LeagueID: nba.NBA})
+	s.Require().NoError(err)
+	s.Empty(sb.Scoreboard.Games)
+}
+
+func (s *ServerTestSuite) TestBoxscore() {
+	ctx := context.Background()
+
+	bs, err := s.c.GetBoxscore(ctx, nba.GetBoxscoreCommand{GameID: "0022200001", LeagueID: nba.NBA})
+	s.Require().NoError(err)
+	s.True(bs.Boxscore.IsFinal())
+	s.Equal(int64(124), bs.Boxscore.HomeTeam.Stats.PT)
+	s.Len(bs.Boxscore.HomeTeam.Players, 6)
+
+	_, err = s.c.GetBoxscore(ctx, nba.GetBoxscoreCommand{GameID: "0022299999", LeagueID: nba.NBA})
+	s.Error(err)
+}
+
+func (s *ServerTestSuite) TestLiveGame() {
+	var (
+		ctx = context.Background()
+		cmd = nba.GetBoxscoreCommand{GameID: "0022200001", LeagueID: nba.NBA}
+	)
+
+	s.fs.StartLive(cmd.GameID, s.now, 2*time.Hour)
+
+	bs, err := s.c.GetBoxscore(ctx, cmd)
+	s.Require().NoError(err)
+	s.Equal(nba.Scheduled, bs.Boxscore.Status)
+	s.Zero(bs.Boxscore.HomeTeam.Stats.PT)
+
+	s.now = s.now.Add(time.Hour)
+
+	bs, err = s.c.GetBoxscore(ctx, cmd)
+	s.Require().NoError(err)
+	s.Equal(nba.Live, bs.Boxscore.Status)
+	s.Equal(int64(62), bs.Boxscore.HomeTeam.Stats.PT)
+	s.Equal("PT120M00.00S", bs.Boxscore.HomeTeam.Stats.Minutes)
+
+	sb, err := s.c.GetScoreboard(ctx, nba.GetScoreboardCommand{Date: "2022-10-18", LeagueID: nba.NBA})
+	s.Require().NoError(err)
+	s.False(sb.Scoreboard.IsFinal())
+
+	s.now = s.now.Add(time.Hour)
+
+	bs, err = s.c.GetBoxscore(ctx, cmd)
+	s.Require().NoError(err)
+	s.True(bs.Boxscore.IsFinal())
+	s.Equal(int64(124), bs.Boxscore.HomeTeam.Stats.PT)
+}
+
+func (s *ServerTestSuite) TestFaults() {
+	var (
+		ctx = context.Background()
+		cmd = nba.GetScoreboardCommand{Date: "2022-10-18", LeagueID: nba.NBA}
+	)
+
+	for _, f := range []nbatest.Faults{
+		{ForbiddenRate: 1},
+		{ThrottledRate: 1},
+		{MalformedRate: 1},
+	} {
+		s.fs.SetFaults(f)
+
+		_, err := s.c.GetScoreboard(ctx, cmd)
+		s.Error(err)
+	}
+
+	s.fs.SetFaults(nbatest.Faults{Latency: 10 * time.Millisecond})
+
+	_, err := s.c.GetScoreboard(ctx, cmd)
+	s.NoError(err)
+}
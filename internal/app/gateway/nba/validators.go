@@ -0,0 +1,94 @@
+package nba
+
+import (
+	"container/list"
+	"net/http"
+	"sync"
+)
+
+// maxValidators bounds how many boxscores are kept for conditional requests
+const maxValidators = 512
+
+type (
+	// validators remembers the ETag and Last-Modified of the latest boxscore of
+	// every URL, with its decoded document, evicting the least recently used.
+	validators struct {
+		mu      sync.Mutex
+		max     int
+		order   *list.List
+		entries map[string]*list.Element
+	}
+
+	validated struct {
+		url          string
+		etag         string
+		lastModified string
+		data         BoxscoreData
+	}
+)
+
+func newValidators(max int) *validators {
+	return &validators{max: max, order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+// apply sets the conditional headers for the cached URL, if any
+func (v *validators) apply(req *http.Request) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	e, ok := v.entries[req.URL.String()]
+	if !ok {
+		return
+	}
+
+	val := e.Value.(*validated)
+
+	if val.etag != "" {
+		req.Header.Set("If-None-Match", val.etag)
+	}
+
+	if val.lastModified != "" {
+		req.Header.Set("If-Modified-Since", val.lastModified)
+	}
+}
+
+// get returns the document stored for the URL
+func (v *validators) get(url string) (BoxscoreData, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	e, ok := v.entries[url]
+	if !ok {
+		return BoxscoreData{}, false
+	}
+
+	v.order.MoveToFront(e)
+
+	return e.Value.(*validated).data, true
+}
+
+// put stores the document when the response carries validators
+func (v *validators) put(url string, h http.Header, data BoxscoreData) {
+	val := &validated{url: url, etag: h.Get("ETag"), lastModified: h.Get("Last-Modified"), data: data}
+	if val.etag == "" && val.lastModified == "" {
+		return
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if e, ok := v.entries[url]; ok {
+		e.Value = val
+		v.order.MoveToFront(e)
+
+		return
+	}
+
+	v.entries[url] = v.order.PushFront(val)
+
+	if v.order.Len() > v.max {
+		oldest := v.order.Back()
+		v.order.Remove(oldest)
+		delete(v.entries, oldest.Value.(*validated).url)
+	}
+}
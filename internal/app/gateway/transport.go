@@ -0,0 +1,182 @@
+package gateway
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	// ModeLive sends every request upstream
+	ModeLive Mode = "live"
+	// ModeRecord sends every request upstream and saves the responses
+	ModeRecord Mode = "record"
+	// ModeReplay answers every request with a saved response
+	ModeReplay Mode = "replay"
+)
+
+var (
+	// ErrNoRecording is returned when replaying a request that was never recorded
+	ErrNoRecording = errors.New("no recording for request")
+
+	unsafeChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+	// recordedHeaders are the only headers kept, everything else (cookies,
+	// tracking ids, etc.) is dropped when recording.
+	recordedHeaders = []string{"Content-Type", "Cache-Control", "ETag", "Last-Modified"}
+)
+
+type (
+	// Mode selects how the upstream transport behaves
+	Mode string
+
+	recording struct {
+		StatusCode int               `json:"status_code"`
+		Header     map[string]string `json:"header,omitempty"`
+		Body       json.RawMessage   `json:"body,omitempty"`
+		BodyText   string            `json:"body_text,omitempty"`
+	}
+
+	recorder struct {
+		dir  string
+		next http.RoundTripper
+	}
+
+	replayer struct {
+		dir string
+	}
+)
+
+// NewTransport returns the round tripper for the mode. Recordings are read
+// from, and written to, dir.
+func NewTransport(mode Mode, dir string) (http.RoundTripper, error) {
+	switch mode {
+	case "", ModeLive:
+		return http.DefaultTransport, nil
+	case ModeRecord:
+		return NewRecorder(dir, http.DefaultTransport), nil
+	case ModeReplay:
+		return NewReplayer(dir), nil
+	default:
+		return nil, fmt.Errorf("unknown transport mode %q", mode)
+	}
+}
+
+// NewRecorder sends requests through next and saves a sanitised copy of every
+// response to dir, keyed by the request URL.
+func NewRecorder(dir string, next http.RoundTripper) http.RoundTripper {
+	return &recorder{dir: dir, next: next}
+}
+
+// NewReplayer answers requests with the responses saved by a recorder.
+func NewReplayer(dir string) http.RoundTripper {
+	return &replayer{dir: dir}
+}
+
+func (rc *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := rc.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	rec := recording{StatusCode: resp.StatusCode, Header: make(map[string]string)}
+
+	for _, h := range recordedHeaders {
+		if v := resp.Header.Get(h); v != "" {
+			rec.Header[h] = v
+		}
+	}
+
+	if json.Valid(body) {
+		rec.Body = body
+	} else {
+		rec.BodyText = string(body)
+	}
+
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode recording: %w", err)
+	}
+
+	if err := os.MkdirAll(rc.dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create recordings directory: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(rc.dir, recordingName(req)), data, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write recording: %w", err)
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	return resp, nil
+}
+
+func (rp *replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	name := recordingName(req)
+
+	data, err := os.ReadFile(filepath.Join(rp.dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s %s", ErrNoRecording, req.Method, req.URL)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+
+	var rec recording
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("failed to decode recording %s: %w", name, err)
+	}
+
+	body := []byte(rec.BodyText)
+	if len(rec.Body) > 0 {
+		body = rec.Body
+	}
+
+	header := make(http.Header, len(rec.Header))
+	for k, v := range rec.Header {
+		header.Set(k, v)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.StatusCode, http.StatusText(rec.StatusCode)),
+		StatusCode:    rec.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// recordingName keys a request by its method, path and sorted query, leaving
+// the host out so recordings replay against any base URL.
+func recordingName(req *http.Request) string {
+	key := req.Method + " " + req.URL.Path
+	if q := req.URL.Query().Encode(); q != "" {
+		key += "?" + q
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	name := strings.Trim(unsafeChars.ReplaceAllString(strings.TrimPrefix(key, req.Method+" "), "_"), "_")
+
+	return fmt.Sprintf("%s_%s.json", name, hex.EncodeToString(sum[:4]))
+}
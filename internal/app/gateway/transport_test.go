@@ -0,0 +1,81 @@
+package gateway_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway"
+	"github.com/stretchr/testify/suite"
+)
+
+type TransportTestSuite struct {
+	suite.Suite
+
+	dir string
+	hs  *httptest.Server
+}
+
+func (s *TransportTestSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+	s.hs = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "secret"})
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"league":"` + r.URL.Query().Get("LeagueID") + `"}`)) //nolint: errcheck
+	}))
+}
+
+func (s *TransportTestSuite) TearDownTest() {
+	s.hs.Close()
+}
+
+func TestTransport(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(TransportTestSuite))
+}
+
+func (s *TransportTestSuite) TestRecordAndReplay() {
+	var (
+		recorder = gateway.NewClientWithTransport(time.Second, gateway.NewRecorder(s.dir, http.DefaultTransport))
+		replayer = gateway.NewClientWithTransport(time.Second, gateway.NewReplayer(s.dir))
+	)
+
+	recorded := s.get(recorder, s.hs.URL+"/stats/scoreboardv3?LeagueID=00&GameDate=2022-10-18")
+	s.Equal(`{"league":"00"}`, recorded)
+
+	s.hs.Close()
+
+	// Query order and host don't matter when replaying.
+	resp, err := replayer.Get("http://nba.test/stats/scoreboardv3?GameDate=2022-10-18&LeagueID=00")
+	s.Require().NoError(err)
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	s.Require().NoError(err)
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal(`"v1"`, resp.Header.Get("ETag"))
+	s.Empty(resp.Header.Get("Set-Cookie"))
+	s.JSONEq(recorded, string(body))
+
+	_, err = replayer.Get("http://nba.test/stats/scoreboardv3?GameDate=2022-10-19&LeagueID=00")
+	s.True(errors.Is(err, gateway.ErrNoRecording))
+}
+
+func (s *TransportTestSuite) get(c *http.Client, url string) string {
+	resp, err := c.Get(url)
+	s.Require().NoError(err)
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	s.Require().NoError(err)
+
+	return string(body)
+}
@@ -0,0 +1,6 @@
+package grpc
+
+import "time"
+
+// SetMinWatchInterval lowers the poll interval floor so tests don't wait.
+func SetMinWatchInterval(d time.Duration) { minWatchInterval = d }
@@ -0,0 +1,177 @@
+package grpc
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/logging"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/metrics"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/ratelimit"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// The metadata of the REST headers, lower case as gRPC has them
+	apiKeyMetadata    = "x-api-key"
+	requestIDMetadata = "x-request-id"
+)
+
+type (
+	callLogKey   struct{}
+	admissionKey struct{}
+
+	// callLog collects what inner interceptors know about the call, for the
+	// access log line
+	callLog struct {
+		client string
+	}
+
+	// serverStream is a stream with the context of the interceptors
+	serverStream struct {
+		grpc.ServerStream
+
+		ctx context.Context
+	}
+)
+
+func (s *serverStream) Context() context.Context { return s.ctx }
+
+func (srv *Server) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	var resp any
+
+	err := srv.observe(ctx, info.FullMethod, func(md metadata.MD) error { return grpc.SetHeader(ctx, md) }, func(ctx context.Context) error {
+		ctx, err := srv.admit(ctx)
+		if err != nil {
+			return err
+		}
+
+		resp, err = handler(ctx, req)
+
+		return err
+	})
+
+	return resp, err
+}
+
+func (srv *Server) streamInterceptor(s any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return srv.observe(ss.Context(), info.FullMethod, ss.SetHeader, func(ctx context.Context) error {
+		ctx, err := srv.admit(ctx)
+		if err != nil {
+			return err
+		}
+
+		return handler(s, &serverStream{ServerStream: ss, ctx: ctx})
+	})
+}
+
+// observe assigns every call an id, taken from x-request-id when the caller
+// sets one, stores a logger tagged with it in the context, and logs and
+// records the call once it is served. It is the access log and the
+// instrumentation of the REST API, for gRPC.
+func (srv *Server) observe(ctx context.Context, method string, setHeader func(metadata.MD) error, call func(context.Context) error) error {
+	var (
+		done  = metrics.CallStarted(method)
+		start = time.Now()
+		md, _ = metadata.FromIncomingContext(ctx)
+		id    = logging.RequestID(first(md, requestIDMetadata))
+	)
+
+	setHeader(metadata.Pairs(requestIDMetadata, id)) //nolint: errcheck
+
+	logger := srv.logger.With("request_id", id)
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		logger = logger.With("trace_id", sc.TraceID().String())
+	}
+
+	cl := &callLog{}
+
+	err := call(context.WithValue(logging.WithLogger(ctx, logger), callLogKey{}, cl))
+
+	code := status.Code(err).String()
+
+	fields := []any{
+		"method", method,
+		"code", code,
+		"latency", time.Since(start),
+	}
+
+	if cl.client != "" {
+		fields = append(fields, "client", cl.client)
+	}
+
+	logger.Infow("call", fields...)
+	done(code)
+
+	return err
+}
+
+// admit rejects calls with unknown API keys as Unauthenticated, and calls
+// over the client limit as ResourceExhausted, sharing the limits of the REST
+// API. The admission is kept in the context for streams to charge every
+// upstream call past the first.
+func (srv *Server) admit(ctx context.Context) (context.Context, error) {
+	if srv.limit == nil {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	ad := srv.limit.Admit(first(md, apiKeyMetadata), clientIP(ctx, md, srv.limit))
+	if ad.UnknownKey {
+		logging.FromContext(ctx).Warnw("rejected unknown api key")
+
+		return ctx, status.Error(codes.Unauthenticated, "invalid API key")
+	}
+
+	if cl, ok := ctx.Value(callLogKey{}).(*callLog); ok {
+		cl.client = ad.Client
+	}
+
+	if ad.Limited && !ad.Allowed {
+		return ctx, status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry in %ds", int(math.Ceil(ad.RetryAfter.Seconds())))
+	}
+
+	return context.WithValue(ctx, admissionKey{}, ad), nil
+}
+
+// charge spends another token of the client of the call, failing with
+// ResourceExhausted once there are none
+func (srv *Server) charge(ctx context.Context) error {
+	ad, ok := ctx.Value(admissionKey{}).(ratelimit.Admission)
+	if !ok || srv.limit.Charge(ad) {
+		return nil
+	}
+
+	return status.Error(codes.ResourceExhausted, "rate limit exceeded")
+}
+
+// clientIP is the address the call is limited by, forwarded in metadata by a
+// trusted proxy or the address of the peer
+func clientIP(ctx context.Context, md metadata.MD, rl *ratelimit.Limiter) string {
+	var forwarded string
+	if h := rl.ClientIPHeader(); h != "" {
+		forwarded = strings.Join(md.Get(strings.ToLower(h)), ",")
+	}
+
+	var addr string
+	if p, ok := peer.FromContext(ctx); ok {
+		addr = p.Addr.String()
+	}
+
+	return rl.ClientIP(addr, forwarded)
+}
+
+func first(md metadata.MD, key string) string {
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+
+	return ""
+}
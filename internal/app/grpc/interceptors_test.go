@@ -0,0 +1,158 @@
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	rpc "github.com/pedro-mealha/nba-stats-api/internal/app/grpc"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/grpc/statspb"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/ratelimit"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+type InterceptorsTestSuite struct {
+	suite.Suite
+
+	pm   *stats.ProviderMock
+	logs *observer.ObservedLogs
+	gs   *grpc.Server
+	conn *grpc.ClientConn
+	c    statspb.StatsServiceClient
+}
+
+func (s *InterceptorsTestSuite) SetupTest() {
+	rl, err := ratelimit.NewLimiter(ratelimit.Limits{
+		Anonymous: ratelimit.Tier{PerMinute: 60, Burst: 1},
+		Tiers:     map[string]ratelimit.Tier{"partner": {PerMinute: 600, Burst: 5}},
+		Keys:      []ratelimit.APIKey{{Key: "secret", Client: "celtics", Tier: "partner"}},
+	})
+	s.Require().NoError(err)
+
+	core, logs := observer.New(zapcore.InfoLevel)
+
+	s.pm = new(stats.ProviderMock)
+	s.logs = logs
+	s.gs = rpc.NewServer(zap.New(core).Sugar(), s.pm, leagues, rpc.WithRateLimiter(rl)).Register()
+
+	lis := bufconn.Listen(1 << 20)
+
+	go s.gs.Serve(lis) //nolint: errcheck
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	s.Require().NoError(err)
+
+	s.conn = conn
+	s.c = statspb.NewStatsServiceClient(conn)
+}
+
+func (s *InterceptorsTestSuite) TearDownTest() {
+	s.conn.Close()
+	s.gs.Stop()
+}
+
+func TestInterceptors(t *testing.T) {
+	rpc.SetMinWatchInterval(time.Millisecond)
+
+	suite.Run(t, new(InterceptorsTestSuite))
+}
+
+func (s *InterceptorsTestSuite) TestUnknownKey() {
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "nope")
+
+	_, err := s.c.GetBoxscore(ctx, &statspb.GetBoxscoreRequest{GameId: "0022200001"})
+	s.Equal(codes.Unauthenticated, status.Code(err))
+	s.pm.AssertNotCalled(s.T(), "GetBoxscore", mock.Anything, mock.Anything)
+}
+
+func (s *InterceptorsTestSuite) TestRateLimit() {
+	s.pm.On("GetBoxscore", mock.Anything, mock.Anything).Return(stats.Boxscore{GameID: "0022200001", Status: nba.Final}, nil)
+
+	_, err := s.c.GetBoxscore(context.Background(), &statspb.GetBoxscoreRequest{GameId: "0022200001"})
+	s.Require().NoError(err)
+
+	_, err = s.c.GetBoxscore(context.Background(), &statspb.GetBoxscoreRequest{GameId: "0022200001"})
+	s.Equal(codes.ResourceExhausted, status.Code(err))
+
+	// Clients with a key have their own bucket.
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "secret")
+
+	_, err = s.c.GetBoxscore(ctx, &statspb.GetBoxscoreRequest{GameId: "0022200001"})
+	s.Require().NoError(err)
+	s.pm.AssertNumberOfCalls(s.T(), "GetBoxscore", 2)
+}
+
+func (s *InterceptorsTestSuite) TestStreamRateLimit() {
+	s.pm.On("GetBoxscore", mock.Anything, mock.Anything).Return(stats.Boxscore{GameID: "0022200001", Status: nba.Final}, nil)
+
+	for _, code := range []codes.Code{codes.OK, codes.ResourceExhausted} {
+		stream, err := s.c.WatchGame(context.Background(), &statspb.WatchGameRequest{GameId: "0022200001"})
+		s.Require().NoError(err)
+
+		_, err = stream.Recv()
+		s.Equal(code, status.Code(err))
+	}
+}
+
+func (s *InterceptorsTestSuite) TestStreamPollsCharged() {
+	s.pm.On("GetBoxscore", mock.Anything, mock.Anything).Return(stats.Boxscore{GameID: "0022200001", Status: nba.Live}, nil)
+
+	stream, err := s.c.WatchGame(context.Background(), &statspb.WatchGameRequest{
+		GameId:   "0022200001",
+		Interval: durationpb.New(time.Millisecond),
+	})
+	s.Require().NoError(err)
+
+	// The admission pays for the first poll, the next one finds the bucket
+	// empty.
+	_, err = stream.Recv()
+	s.Require().NoError(err)
+
+	_, err = stream.Recv()
+	s.Equal(codes.ResourceExhausted, status.Code(err))
+	s.pm.AssertNumberOfCalls(s.T(), "GetBoxscore", 1)
+}
+
+func (s *InterceptorsTestSuite) TestAccessLog() {
+	s.pm.On("GetBoxscore", mock.Anything, mock.Anything).Return(stats.Boxscore{}, errFailed)
+
+	var header metadata.MD
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "secret", "x-request-id", "abc-123")
+
+	_, err := s.c.GetBoxscore(ctx, &statspb.GetBoxscoreRequest{GameId: "0022200001"}, grpc.Header(&header))
+	s.Equal(codes.Unavailable, status.Code(err))
+	s.Equal([]string{"abc-123"}, header.Get("x-request-id"))
+
+	// The handler logs through the logger of the call.
+	failed := s.logs.FilterMessage("failed to get boxscore").All()
+	s.Require().Len(failed, 1)
+	s.Equal("abc-123", failed[0].ContextMap()["request_id"])
+
+	calls := s.logs.FilterMessage("call").All()
+	s.Require().Len(calls, 1)
+
+	fields := calls[0].ContextMap()
+	s.Equal("/nba.stats.v1.StatsService/GetBoxscore", fields["method"])
+	s.Equal("Unavailable", fields["code"])
+	s.Equal("key:celtics", fields["client"])
+	s.Equal("abc-123", fields["request_id"])
+}
@@ -0,0 +1,310 @@
+// Package grpc exposes the stats domain as the nba.stats.v1.StatsService.
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/grpc/statspb"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/logging"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/ratelimit"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultWatchInterval = 10 * time.Second
+	// maxWatchFailures is how many polls in a row may fail before a watch
+	// gives up
+	maxWatchFailures = 5
+)
+
+// minWatchInterval protects the upstream from clients polling too eagerly
+var minWatchInterval = 2 * time.Second
+
+// Server implements statspb.StatsServiceServer on top of the stats domain
+type Server struct {
+	statspb.UnimplementedStatsServiceServer
+
+	logger  *zap.SugaredLogger
+	s       stats.Provider
+	leagues *nba.Registry
+	limit   *ratelimit.Limiter
+}
+
+// Option configures the Server
+type Option func(*Server)
+
+// WithRateLimiter enforces the API keys and client limits of the REST API on
+// every call
+func WithRateLimiter(rl *ratelimit.Limiter) Option {
+	return func(srv *Server) {
+		srv.limit = rl
+	}
+}
+
+// NewServer creates a new gRPC stats server, serving the leagues of the
+// registry
+func NewServer(logger *zap.SugaredLogger, s stats.Provider, leagues *nba.Registry, opts ...Option) *Server {
+	srv := &Server{logger: logger, s: s, leagues: leagues}
+
+	for _, opt := range opts {
+		opt(srv)
+	}
+
+	return srv
+}
+
+// Register creates a grpc.Server with the stats service registered, traced,
+// logged and rate limited like the REST API
+func (srv *Server) Register(opts ...grpc.ServerOption) *grpc.Server {
+	opts = append([]grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(srv.unaryInterceptor),
+		grpc.ChainStreamInterceptor(srv.streamInterceptor),
+	}, opts...)
+
+	gs := grpc.NewServer(opts...)
+	statspb.RegisterStatsServiceServer(gs, srv)
+
+	return gs
+}
+
+func (srv *Server) GetScoreboard(ctx context.Context, req *statspb.GetScoreboardRequest) (*statspb.Scoreboard, error) {
+	league, err := srv.leagueID(req.GetLeague(), req.GetLeagueName(), "")
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := srv.s.GetScoreboard(ctx, nba.GetScoreboardCommand{Date: req.GetDate(), LeagueID: league})
+	if err != nil {
+		logging.FromContext(ctx).Errorw("failed to get scoreboard", "err", err)
+
+		return nil, status.Error(codes.Unavailable, "failed to get scoreboard")
+	}
+
+	return newScoreboard(res), nil
+}
+
+func (srv *Server) GetBoxscore(ctx context.Context, req *statspb.GetBoxscoreRequest) (*statspb.Boxscore, error) {
+	if req.GetGameId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "game_id is required")
+	}
+
+	league, err := srv.leagueID(req.GetLeague(), req.GetLeagueName(), req.GetGameId())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := srv.s.GetBoxscore(ctx, nba.GetBoxscoreCommand{GameID: req.GetGameId(), LeagueID: league})
+	if errors.Is(err, nba.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "game %s not found", req.GetGameId())
+	}
+
+	if err != nil {
+		logging.FromContext(ctx).Errorw("failed to get boxscore", "err", err)
+
+		return nil, status.Error(codes.Unavailable, "failed to get boxscore")
+	}
+
+	return newBoxscore(res), nil
+}
+
+// WatchGame polls the box score and sends it whenever it changes, finishing
+// once the game is final. Unknown games end it with NotFound, and so many
+// failed polls in a row with Unavailable. Every poll past the first takes a
+// token of the client.
+func (srv *Server) WatchGame(req *statspb.WatchGameRequest, stream statspb.StatsService_WatchGameServer) error {
+	if req.GetGameId() == "" {
+		return status.Error(codes.InvalidArgument, "game_id is required")
+	}
+
+	league, err := srv.leagueID(req.GetLeague(), req.GetLeagueName(), req.GetGameId())
+	if err != nil {
+		return err
+	}
+
+	interval := defaultWatchInterval
+	if req.GetInterval() != nil {
+		interval = max(req.GetInterval().AsDuration(), minWatchInterval)
+	}
+
+	var (
+		// Polls must not hold up one-off requests on the upstream limiter.
+		ctx      = gateway.WithPriority(stream.Context(), gateway.PriorityBackground)
+		cmd      = nba.GetBoxscoreCommand{GameID: req.GetGameId(), LeagueID: league}
+		ticker   = time.NewTicker(interval)
+		last     *statspb.Boxscore
+		failures int
+	)
+
+	defer ticker.Stop()
+
+	for polls := 0; ; polls++ {
+		if polls > 0 {
+			if err := srv.charge(ctx); err != nil {
+				return err
+			}
+		}
+
+		res, err := srv.s.GetBoxscore(ctx, cmd)
+
+		switch {
+		case errors.Is(err, nba.ErrNotFound):
+			return status.Errorf(codes.NotFound, "game %s not found", cmd.GameID)
+		case err != nil:
+			failures++
+
+			// A poll failing mid game is not fatal, the next one may succeed.
+			logging.FromContext(ctx).Warnw("failed to poll boxscore", "err", err, "game_id", cmd.GameID, "failures", failures)
+
+			if failures >= maxWatchFailures {
+				return status.Error(codes.Unavailable, "failed to poll boxscore")
+			}
+		default:
+			failures = 0
+
+			if b := newBoxscore(res); !proto.Equal(b, last) {
+				if err := stream.Send(b); err != nil {
+					return err
+				}
+
+				last = b
+			}
+		}
+
+		if last.GetStatus() == statspb.GameStatus_GAME_STATUS_FINAL {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// leagueIDs are the leagues of the enum
+var leagueIDs = map[statspb.League]nba.LeagueID{
+	statspb.League_LEAGUE_NBA:           nba.NBA,
+	statspb.League_LEAGUE_WNBA:          nba.WNBA,
+	statspb.League_LEAGUE_G_LEAGUE:      nba.GLeague,
+	statspb.League_LEAGUE_SUMMER_LEAGUE: nba.SummerLeague,
+}
+
+// leagueID resolves the league in the registry, by name when one is given.
+// Without either it is the league of the game id, if any, like in REST, or
+// the default league.
+func (srv *Server) leagueID(l statspb.League, name, gameID string) (nba.LeagueID, error) {
+	reg := srv.leagues
+
+	if name != "" {
+		if league := reg.ByName(name); league.Name == name {
+			return league.ID, nil
+		}
+
+		return "", status.Errorf(codes.InvalidArgument, "unknown league %q", name)
+	}
+
+	id, ok := leagueIDs[l]
+	if !ok {
+		if league, ok := reg.ByGameID(gameID); ok {
+			return league.ID, nil
+		}
+
+		return reg.Default().ID, nil
+	}
+
+	if reg.Get(id).ID != id {
+		return "", status.Errorf(codes.InvalidArgument, "league %s isn't served", l)
+	}
+
+	return id, nil
+}
+
+func newScoreboard(sb stats.Scoreboard) *statspb.Scoreboard {
+	games := make([]*statspb.Game, len(sb.Games))
+
+	for i, g := range sb.Games {
+		games[i] = &statspb.Game{
+			Id:       g.ID,
+			Status:   statspb.GameStatus(g.Status),
+			StartsAt: timestamppb.New(time.Time(g.StartsAt)),
+			HomeTeam: newTeam(g.HomeTeam),
+			AwayTeam: newTeam(g.AwayTeam),
+		}
+	}
+
+	return &statspb.Scoreboard{
+		Date:  time.Time(sb.Date).Format("2006-01-02"),
+		Games: games,
+	}
+}
+
+func newBoxscore(b stats.Boxscore) *statspb.Boxscore {
+	return &statspb.Boxscore{
+		GameId:   b.GameID,
+		Status:   statspb.GameStatus(b.Status),
+		HomeTeam: newTeam(b.HomeTeam),
+		AwayTeam: newTeam(b.AwayTeam),
+	}
+}
+
+func newTeam(t stats.Team) *statspb.Team {
+	players := make([]*statspb.Player, len(t.Players))
+
+	for i, p := range t.Players {
+		players[i] = &statspb.Player{
+			FirstName: p.FirstName,
+			LastName:  p.LastName,
+			Position:  p.Position,
+			Stats:     newStats(p.Stats),
+		}
+	}
+
+	return &statspb.Team{
+		Id:      t.ID,
+		Name:    t.Name,
+		Tricode: t.Tricode,
+		Stats:   newStats(t.Stats),
+		Players: players,
+	}
+}
+
+func newStats(s stats.Stats) *statspb.Stats {
+	return &statspb.Stats{
+		Minutes:   s.Minutes,
+		Fgm:       s.FGM,
+		Fga:       s.FGA,
+		Fgp:       s.FGP,
+		ThreeFgm:  s.ThreeFGM,
+		ThreeFga:  s.ThreeFGA,
+		ThreeFgp:  s.ThreeFGP,
+		Ftm:       s.FTM,
+		Fta:       s.FTA,
+		Ftp:       s.FTP,
+		Oreb:      s.RO,
+		Dreb:      s.RD,
+		Reb:       s.RT,
+		TeamReb:   s.RTeam,
+		Ast:       s.AST,
+		Stl:       s.STL,
+		Blk:       s.BLK,
+		To:        s.TO,
+		TeamTo:    s.TOT,
+		Pf:        s.FP,
+		Fd:        s.FD,
+		Pts:       s.PT,
+		PlusMinus: s.PlusMinus,
+	}
+}
@@ -0,0 +1,204 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba/nbatest"
+	rpc "github.com/pedro-mealha/nba-stats-api/internal/app/grpc"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/grpc/statspb"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+var (
+	errFailed = errors.New("failed")
+
+	leagues = nbatest.Leagues("https://nba.test")
+)
+
+type ServerTestSuite struct {
+	suite.Suite
+
+	pm   *stats.ProviderMock
+	gs   *grpc.Server
+	conn *grpc.ClientConn
+	c    statspb.StatsServiceClient
+}
+
+func (s *ServerTestSuite) SetupTest() {
+	s.pm = new(stats.ProviderMock)
+	s.gs = rpc.NewServer(zap.NewNop().Sugar(), s.pm, leagues).Register()
+
+	lis := bufconn.Listen(1 << 20)
+
+	go s.gs.Serve(lis) //nolint: errcheck
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	s.Require().NoError(err)
+
+	s.conn = conn
+	s.c = statspb.NewStatsServiceClient(conn)
+}
+
+func (s *ServerTestSuite) TearDownTest() {
+	s.conn.Close()
+	s.gs.Stop()
+}
+
+func TestServer(t *testing.T) {
+	rpc.SetMinWatchInterval(time.Millisecond)
+
+	suite.Run(t, new(ServerTestSuite))
+}
+
+func (s *ServerTestSuite) TestGetScoreboard() {
+	sb := stats.Scoreboard{
+		Date: nba.GameDate(time.Date(2022, 10, 18, 0, 0, 0, 0, time.UTC)),
+		Games: []stats.Game{
+			{
+				ID:       "0022200001",
+				Status:   nba.Final,
+				StartsAt: nba.GameTime(time.Date(2022, 10, 18, 23, 30, 0, 0, time.UTC)),
+				HomeTeam: stats.Team{ID: 1610612738, Tricode: "BOS"},
+			},
+		},
+	}
+
+	s.pm.On("GetScoreboard", mock.Anything, nba.GetScoreboardCommand{Date: "2022-10-18", LeagueID: nba.NBA}).Return(sb, nil)
+
+	res, err := s.c.GetScoreboard(context.Background(), &statspb.GetScoreboardRequest{Date: "2022-10-18"})
+	s.Require().NoError(err)
+
+	s.Equal("2022-10-18", res.GetDate())
+	s.Len(res.GetGames(), 1)
+	s.Equal(statspb.GameStatus_GAME_STATUS_FINAL, res.GetGames()[0].GetStatus())
+	s.Equal("BOS", res.GetGames()[0].GetHomeTeam().GetTricode())
+	s.Equal(time.Date(2022, 10, 18, 23, 30, 0, 0, time.UTC), res.GetGames()[0].GetStartsAt().AsTime())
+}
+
+func (s *ServerTestSuite) TestGetBoxscore() {
+	cmd := nba.GetBoxscoreCommand{GameID: "1022200001", LeagueID: nba.WNBA}
+
+	s.pm.On("GetBoxscore", mock.Anything, cmd).Return(stats.Boxscore{
+		GameID:   cmd.GameID,
+		HomeTeam: stats.Team{Stats: stats.Stats{PT: 90, ThreeFGM: 8, RTeam: 3}},
+	}, nil).Once()
+
+	res, err := s.c.GetBoxscore(context.Background(), &statspb.GetBoxscoreRequest{GameId: cmd.GameID, League: statspb.League_LEAGUE_WNBA})
+	s.Require().NoError(err)
+
+	s.Equal(int64(90), res.GetHomeTeam().GetStats().GetPts())
+	s.Equal(int64(8), res.GetHomeTeam().GetStats().GetThreeFgm())
+	s.Equal(int64(3), res.GetHomeTeam().GetStats().GetTeamReb())
+
+	s.pm.On("GetBoxscore", mock.Anything, cmd).Return(stats.Boxscore{}, errFailed).Once()
+
+	_, err = s.c.GetBoxscore(context.Background(), &statspb.GetBoxscoreRequest{GameId: cmd.GameID, League: statspb.League_LEAGUE_WNBA})
+	s.Equal(codes.Unavailable, status.Code(err))
+
+	_, err = s.c.GetBoxscore(context.Background(), &statspb.GetBoxscoreRequest{})
+	s.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestLeagues() {
+	for _, tt := range []struct {
+		req *statspb.GetBoxscoreRequest
+
+		expLeague nba.LeagueID
+	}{
+		{req: &statspb.GetBoxscoreRequest{League: statspb.League_LEAGUE_G_LEAGUE}, expLeague: nba.GLeague},
+		{req: &statspb.GetBoxscoreRequest{League: statspb.League_LEAGUE_SUMMER_LEAGUE}, expLeague: nba.SummerLeague},
+		{req: &statspb.GetBoxscoreRequest{LeagueName: "gleague", League: statspb.League_LEAGUE_WNBA}, expLeague: nba.GLeague},
+		{req: &statspb.GetBoxscoreRequest{League: statspb.League_LEAGUE_NBA}, expLeague: nba.NBA},
+		// Without a league, it is the one of the game id.
+		{req: &statspb.GetBoxscoreRequest{}, expLeague: nba.GLeague},
+	} {
+		tt.req.GameId = "2022200001"
+
+		s.pm.On("GetBoxscore", mock.Anything, nba.GetBoxscoreCommand{GameID: tt.req.GameId, LeagueID: tt.expLeague}).Return(stats.Boxscore{}, nil).Once()
+
+		_, err := s.c.GetBoxscore(context.Background(), tt.req)
+		s.NoError(err, tt.req.String())
+	}
+
+	_, err := s.c.GetBoxscore(context.Background(), &statspb.GetBoxscoreRequest{GameId: "2022200001", LeagueName: "euroleague"})
+	s.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestWatchGame() {
+	cmd := nba.GetBoxscoreCommand{GameID: "0022200001", LeagueID: nba.NBA}
+
+	for _, b := range []stats.Boxscore{
+		{GameID: cmd.GameID, Status: nba.Live, HomeTeam: stats.Team{Stats: stats.Stats{PT: 10}}},
+		{GameID: cmd.GameID, Status: nba.Live, HomeTeam: stats.Team{Stats: stats.Stats{PT: 10}}},
+		{GameID: cmd.GameID, Status: nba.Live, HomeTeam: stats.Team{Stats: stats.Stats{PT: 12}}},
+		{GameID: cmd.GameID, Status: nba.Final, HomeTeam: stats.Team{Stats: stats.Stats{PT: 12}}},
+	} {
+		s.pm.On("GetBoxscore", mock.Anything, cmd).Return(b, nil).Once()
+	}
+
+	stream, err := s.c.WatchGame(context.Background(), &statspb.WatchGameRequest{
+		GameId:   cmd.GameID,
+		Interval: durationpb.New(time.Millisecond),
+	})
+	s.Require().NoError(err)
+
+	var points []int64
+
+	for {
+		b, err := stream.Recv()
+		if err != nil {
+			break
+		}
+
+		points = append(points, b.GetHomeTeam().GetStats().GetPts())
+	}
+
+	// Unchanged polls are not sent and the stream ends with the final one.
+	s.Equal([]int64{10, 12, 12}, points)
+	s.pm.AssertNumberOfCalls(s.T(), "GetBoxscore", 4)
+}
+
+func (s *ServerTestSuite) TestWatchGameNotFound() {
+	s.pm.On("GetBoxscore", mock.Anything, mock.Anything).Return(stats.Boxscore{}, fmt.Errorf("failed to get boxscore: %w", nba.ErrNotFound))
+
+	stream, err := s.c.WatchGame(context.Background(), &statspb.WatchGameRequest{GameId: "0022299999"})
+	s.Require().NoError(err)
+
+	_, err = stream.Recv()
+	s.Equal(codes.NotFound, status.Code(err))
+	s.pm.AssertNumberOfCalls(s.T(), "GetBoxscore", 1)
+}
+
+func (s *ServerTestSuite) TestWatchGameFailures() {
+	s.pm.On("GetBoxscore", mock.Anything, mock.Anything).Return(stats.Boxscore{}, errFailed)
+
+	stream, err := s.c.WatchGame(context.Background(), &statspb.WatchGameRequest{
+		GameId:   "0022200001",
+		Interval: durationpb.New(time.Millisecond),
+	})
+	s.Require().NoError(err)
+
+	// The watch gives up after five failed polls in a row.
+	_, err = stream.Recv()
+	s.Equal(codes.Unavailable, status.Code(err))
+	s.pm.AssertNumberOfCalls(s.T(), "GetBoxscore", 5)
+}
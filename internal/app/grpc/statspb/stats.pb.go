@@ -0,0 +1,1266 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: nba/stats/v1/stats.proto
+
+package statspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// League is one of the built-in leagues. Other leagues, like the ones added
+// with a leagues file, are asked for by their league_name.
+type League int32
+
+const (
+	League_LEAGUE_UNSPECIFIED   League = 0
+	League_LEAGUE_NBA           League = 1
+	League_LEAGUE_WNBA          League = 2
+	League_LEAGUE_G_LEAGUE      League = 3
+	League_LEAGUE_SUMMER_LEAGUE League = 4
+)
+
+// Enum value maps for League.
+var (
+	League_name = map[int32]string{
+		0: "LEAGUE_UNSPECIFIED",
+		1: "LEAGUE_NBA",
+		2: "LEAGUE_WNBA",
+		3: "LEAGUE_G_LEAGUE",
+		4: "LEAGUE_SUMMER_LEAGUE",
+	}
+	League_value = map[string]int32{
+		"LEAGUE_UNSPECIFIED":   0,
+		"LEAGUE_NBA":           1,
+		"LEAGUE_WNBA":          2,
+		"LEAGUE_G_LEAGUE":      3,
+		"LEAGUE_SUMMER_LEAGUE": 4,
+	}
+)
+
+func (x League) Enum() *League {
+	p := new(League)
+	*p = x
+	return p
+}
+
+func (x League) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (League) Descriptor() protoreflect.EnumDescriptor {
+	return file_nba_stats_v1_stats_proto_enumTypes[0].Descriptor()
+}
+
+func (League) Type() protoreflect.EnumType {
+	return &file_nba_stats_v1_stats_proto_enumTypes[0]
+}
+
+func (x League) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use League.Descriptor instead.
+func (League) EnumDescriptor() ([]byte, []int) {
+	return file_nba_stats_v1_stats_proto_rawDescGZIP(), []int{0}
+}
+
+type GameStatus int32
+
+const (
+	GameStatus_GAME_STATUS_UNSPECIFIED GameStatus = 0
+	GameStatus_GAME_STATUS_SCHEDULED   GameStatus = 1
+	GameStatus_GAME_STATUS_LIVE        GameStatus = 2
+	GameStatus_GAME_STATUS_FINAL       GameStatus = 3
+)
+
+// Enum value maps for GameStatus.
+var (
+	GameStatus_name = map[int32]string{
+		0: "GAME_STATUS_UNSPECIFIED",
+		1: "GAME_STATUS_SCHEDULED",
+		2: "GAME_STATUS_LIVE",
+		3: "GAME_STATUS_FINAL",
+	}
+	GameStatus_value = map[string]int32{
+		"GAME_STATUS_UNSPECIFIED": 0,
+		"GAME_STATUS_SCHEDULED":   1,
+		"GAME_STATUS_LIVE":        2,
+		"GAME_STATUS_FINAL":       3,
+	}
+)
+
+func (x GameStatus) Enum() *GameStatus {
+	p := new(GameStatus)
+	*p = x
+	return p
+}
+
+func (x GameStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GameStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_nba_stats_v1_stats_proto_enumTypes[1].Descriptor()
+}
+
+func (GameStatus) Type() protoreflect.EnumType {
+	return &file_nba_stats_v1_stats_proto_enumTypes[1]
+}
+
+func (x GameStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GameStatus.Descriptor instead.
+func (GameStatus) EnumDescriptor() ([]byte, []int) {
+	return file_nba_stats_v1_stats_proto_rawDescGZIP(), []int{1}
+}
+
+type GetScoreboardRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Day of the games as YYYY-MM-DD. Defaults to today.
+	Date   string `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	League League `protobuf:"varint,2,opt,name=league,proto3,enum=nba.stats.v1.League" json:"league,omitempty"`
+	// Name of the league, like gleague, instead of league.
+	LeagueName string `protobuf:"bytes,3,opt,name=league_name,json=leagueName,proto3" json:"league_name,omitempty"`
+}
+
+func (x *GetScoreboardRequest) Reset() {
+	*x = GetScoreboardRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nba_stats_v1_stats_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetScoreboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScoreboardRequest) ProtoMessage() {}
+
+func (x *GetScoreboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nba_stats_v1_stats_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScoreboardRequest.ProtoReflect.Descriptor instead.
+func (*GetScoreboardRequest) Descriptor() ([]byte, []int) {
+	return file_nba_stats_v1_stats_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetScoreboardRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *GetScoreboardRequest) GetLeague() League {
+	if x != nil {
+		return x.League
+	}
+	return League_LEAGUE_UNSPECIFIED
+}
+
+func (x *GetScoreboardRequest) GetLeagueName() string {
+	if x != nil {
+		return x.LeagueName
+	}
+	return ""
+}
+
+type GetBoxscoreRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GameId string `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	League League `protobuf:"varint,2,opt,name=league,proto3,enum=nba.stats.v1.League" json:"league,omitempty"`
+	// Name of the league, like gleague, instead of league.
+	// Without either, it is the league of the game id.
+	LeagueName string `protobuf:"bytes,3,opt,name=league_name,json=leagueName,proto3" json:"league_name,omitempty"`
+}
+
+func (x *GetBoxscoreRequest) Reset() {
+	*x = GetBoxscoreRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nba_stats_v1_stats_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBoxscoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBoxscoreRequest) ProtoMessage() {}
+
+func (x *GetBoxscoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nba_stats_v1_stats_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBoxscoreRequest.ProtoReflect.Descriptor instead.
+func (*GetBoxscoreRequest) Descriptor() ([]byte, []int) {
+	return file_nba_stats_v1_stats_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetBoxscoreRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *GetBoxscoreRequest) GetLeague() League {
+	if x != nil {
+		return x.League
+	}
+	return League_LEAGUE_UNSPECIFIED
+}
+
+func (x *GetBoxscoreRequest) GetLeagueName() string {
+	if x != nil {
+		return x.LeagueName
+	}
+	return ""
+}
+
+type WatchGameRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GameId string `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	League League `protobuf:"varint,2,opt,name=league,proto3,enum=nba.stats.v1.League" json:"league,omitempty"`
+	// How often the upstream is polled. Defaults to 10s, at least 2s.
+	Interval *durationpb.Duration `protobuf:"bytes,3,opt,name=interval,proto3" json:"interval,omitempty"`
+	// Name of the league, like gleague, instead of league.
+	// Without either, it is the league of the game id.
+	LeagueName string `protobuf:"bytes,4,opt,name=league_name,json=leagueName,proto3" json:"league_name,omitempty"`
+}
+
+func (x *WatchGameRequest) Reset() {
+	*x = WatchGameRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nba_stats_v1_stats_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchGameRequest) ProtoMessage() {}
+
+func (x *WatchGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nba_stats_v1_stats_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchGameRequest.ProtoReflect.Descriptor instead.
+func (*WatchGameRequest) Descriptor() ([]byte, []int) {
+	return file_nba_stats_v1_stats_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WatchGameRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *WatchGameRequest) GetLeague() League {
+	if x != nil {
+		return x.League
+	}
+	return League_LEAGUE_UNSPECIFIED
+}
+
+func (x *WatchGameRequest) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *WatchGameRequest) GetLeagueName() string {
+	if x != nil {
+		return x.LeagueName
+	}
+	return ""
+}
+
+type Scoreboard struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Day of the games as YYYY-MM-DD.
+	Date  string  `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Games []*Game `protobuf:"bytes,2,rep,name=games,proto3" json:"games,omitempty"`
+}
+
+func (x *Scoreboard) Reset() {
+	*x = Scoreboard{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nba_stats_v1_stats_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Scoreboard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Scoreboard) ProtoMessage() {}
+
+func (x *Scoreboard) ProtoReflect() protoreflect.Message {
+	mi := &file_nba_stats_v1_stats_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Scoreboard.ProtoReflect.Descriptor instead.
+func (*Scoreboard) Descriptor() ([]byte, []int) {
+	return file_nba_stats_v1_stats_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Scoreboard) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *Scoreboard) GetGames() []*Game {
+	if x != nil {
+		return x.Games
+	}
+	return nil
+}
+
+type Game struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status   GameStatus             `protobuf:"varint,2,opt,name=status,proto3,enum=nba.stats.v1.GameStatus" json:"status,omitempty"`
+	StartsAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	HomeTeam *Team                  `protobuf:"bytes,4,opt,name=home_team,json=homeTeam,proto3" json:"home_team,omitempty"`
+	AwayTeam *Team                  `protobuf:"bytes,5,opt,name=away_team,json=awayTeam,proto3" json:"away_team,omitempty"`
+}
+
+func (x *Game) Reset() {
+	*x = Game{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nba_stats_v1_stats_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Game) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Game) ProtoMessage() {}
+
+func (x *Game) ProtoReflect() protoreflect.Message {
+	mi := &file_nba_stats_v1_stats_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Game.ProtoReflect.Descriptor instead.
+func (*Game) Descriptor() ([]byte, []int) {
+	return file_nba_stats_v1_stats_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Game) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Game) GetStatus() GameStatus {
+	if x != nil {
+		return x.Status
+	}
+	return GameStatus_GAME_STATUS_UNSPECIFIED
+}
+
+func (x *Game) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *Game) GetHomeTeam() *Team {
+	if x != nil {
+		return x.HomeTeam
+	}
+	return nil
+}
+
+func (x *Game) GetAwayTeam() *Team {
+	if x != nil {
+		return x.AwayTeam
+	}
+	return nil
+}
+
+type Boxscore struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GameId   string     `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	Status   GameStatus `protobuf:"varint,2,opt,name=status,proto3,enum=nba.stats.v1.GameStatus" json:"status,omitempty"`
+	HomeTeam *Team      `protobuf:"bytes,3,opt,name=home_team,json=homeTeam,proto3" json:"home_team,omitempty"`
+	AwayTeam *Team      `protobuf:"bytes,4,opt,name=away_team,json=awayTeam,proto3" json:"away_team,omitempty"`
+}
+
+func (x *Boxscore) Reset() {
+	*x = Boxscore{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nba_stats_v1_stats_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Boxscore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Boxscore) ProtoMessage() {}
+
+func (x *Boxscore) ProtoReflect() protoreflect.Message {
+	mi := &file_nba_stats_v1_stats_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Boxscore.ProtoReflect.Descriptor instead.
+func (*Boxscore) Descriptor() ([]byte, []int) {
+	return file_nba_stats_v1_stats_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Boxscore) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *Boxscore) GetStatus() GameStatus {
+	if x != nil {
+		return x.Status
+	}
+	return GameStatus_GAME_STATUS_UNSPECIFIED
+}
+
+func (x *Boxscore) GetHomeTeam() *Team {
+	if x != nil {
+		return x.HomeTeam
+	}
+	return nil
+}
+
+func (x *Boxscore) GetAwayTeam() *Team {
+	if x != nil {
+		return x.AwayTeam
+	}
+	return nil
+}
+
+type Team struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name    string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Tricode string `protobuf:"bytes,3,opt,name=tricode,proto3" json:"tricode,omitempty"`
+	// Zeroed for scoreboard teams.
+	Stats *Stats `protobuf:"bytes,4,opt,name=stats,proto3" json:"stats,omitempty"`
+	// Only set for box score teams.
+	Players []*Player `protobuf:"bytes,5,rep,name=players,proto3" json:"players,omitempty"`
+}
+
+func (x *Team) Reset() {
+	*x = Team{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nba_stats_v1_stats_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Team) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Team) ProtoMessage() {}
+
+func (x *Team) ProtoReflect() protoreflect.Message {
+	mi := &file_nba_stats_v1_stats_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Team.ProtoReflect.Descriptor instead.
+func (*Team) Descriptor() ([]byte, []int) {
+	return file_nba_stats_v1_stats_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Team) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Team) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Team) GetTricode() string {
+	if x != nil {
+		return x.Tricode
+	}
+	return ""
+}
+
+func (x *Team) GetStats() *Stats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *Team) GetPlayers() []*Player {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+type Player struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FirstName string `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName  string `protobuf:"bytes,2,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	Position  string `protobuf:"bytes,3,opt,name=position,proto3" json:"position,omitempty"`
+	Stats     *Stats `protobuf:"bytes,4,opt,name=stats,proto3" json:"stats,omitempty"`
+}
+
+func (x *Player) Reset() {
+	*x = Player{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nba_stats_v1_stats_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Player) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Player) ProtoMessage() {}
+
+func (x *Player) ProtoReflect() protoreflect.Message {
+	mi := &file_nba_stats_v1_stats_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Player.ProtoReflect.Descriptor instead.
+func (*Player) Descriptor() ([]byte, []int) {
+	return file_nba_stats_v1_stats_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Player) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *Player) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *Player) GetPosition() string {
+	if x != nil {
+		return x.Position
+	}
+	return ""
+}
+
+func (x *Player) GetStats() *Stats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+// Stats is a stat line. Percentages go from 0 to 100.
+type Stats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Minutes played, as minutes:seconds.
+	Minutes   string  `protobuf:"bytes,1,opt,name=minutes,proto3" json:"minutes,omitempty"`
+	Fgm       int64   `protobuf:"varint,2,opt,name=fgm,proto3" json:"fgm,omitempty"`
+	Fga       int64   `protobuf:"varint,3,opt,name=fga,proto3" json:"fga,omitempty"`
+	Fgp       float64 `protobuf:"fixed64,4,opt,name=fgp,proto3" json:"fgp,omitempty"`
+	ThreeFgm  int64   `protobuf:"varint,5,opt,name=three_fgm,json=threeFgm,proto3" json:"three_fgm,omitempty"`
+	ThreeFga  int64   `protobuf:"varint,6,opt,name=three_fga,json=threeFga,proto3" json:"three_fga,omitempty"`
+	ThreeFgp  float64 `protobuf:"fixed64,7,opt,name=three_fgp,json=threeFgp,proto3" json:"three_fgp,omitempty"`
+	Ftm       int64   `protobuf:"varint,8,opt,name=ftm,proto3" json:"ftm,omitempty"`
+	Fta       int64   `protobuf:"varint,9,opt,name=fta,proto3" json:"fta,omitempty"`
+	Ftp       float64 `protobuf:"fixed64,10,opt,name=ftp,proto3" json:"ftp,omitempty"`
+	Oreb      int64   `protobuf:"varint,11,opt,name=oreb,proto3" json:"oreb,omitempty"`
+	Dreb      int64   `protobuf:"varint,12,opt,name=dreb,proto3" json:"dreb,omitempty"`
+	Reb       int64   `protobuf:"varint,13,opt,name=reb,proto3" json:"reb,omitempty"`
+	TeamReb   int64   `protobuf:"varint,14,opt,name=team_reb,json=teamReb,proto3" json:"team_reb,omitempty"`
+	Ast       int64   `protobuf:"varint,15,opt,name=ast,proto3" json:"ast,omitempty"`
+	Stl       int64   `protobuf:"varint,16,opt,name=stl,proto3" json:"stl,omitempty"`
+	Blk       int64   `protobuf:"varint,17,opt,name=blk,proto3" json:"blk,omitempty"`
+	To        int64   `protobuf:"varint,18,opt,name=to,proto3" json:"to,omitempty"`
+	TeamTo    int64   `protobuf:"varint,19,opt,name=team_to,json=teamTo,proto3" json:"team_to,omitempty"`
+	Pf        int64   `protobuf:"varint,20,opt,name=pf,proto3" json:"pf,omitempty"`
+	Fd        int64   `protobuf:"varint,21,opt,name=fd,proto3" json:"fd,omitempty"`
+	Pts       int64   `protobuf:"varint,22,opt,name=pts,proto3" json:"pts,omitempty"`
+	PlusMinus float64 `protobuf:"fixed64,23,opt,name=plus_minus,json=plusMinus,proto3" json:"plus_minus,omitempty"`
+}
+
+func (x *Stats) Reset() {
+	*x = Stats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nba_stats_v1_stats_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Stats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stats) ProtoMessage() {}
+
+func (x *Stats) ProtoReflect() protoreflect.Message {
+	mi := &file_nba_stats_v1_stats_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stats.ProtoReflect.Descriptor instead.
+func (*Stats) Descriptor() ([]byte, []int) {
+	return file_nba_stats_v1_stats_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Stats) GetMinutes() string {
+	if x != nil {
+		return x.Minutes
+	}
+	return ""
+}
+
+func (x *Stats) GetFgm() int64 {
+	if x != nil {
+		return x.Fgm
+	}
+	return 0
+}
+
+func (x *Stats) GetFga() int64 {
+	if x != nil {
+		return x.Fga
+	}
+	return 0
+}
+
+func (x *Stats) GetFgp() float64 {
+	if x != nil {
+		return x.Fgp
+	}
+	return 0
+}
+
+func (x *Stats) GetThreeFgm() int64 {
+	if x != nil {
+		return x.ThreeFgm
+	}
+	return 0
+}
+
+func (x *Stats) GetThreeFga() int64 {
+	if x != nil {
+		return x.ThreeFga
+	}
+	return 0
+}
+
+func (x *Stats) GetThreeFgp() float64 {
+	if x != nil {
+		return x.ThreeFgp
+	}
+	return 0
+}
+
+func (x *Stats) GetFtm() int64 {
+	if x != nil {
+		return x.Ftm
+	}
+	return 0
+}
+
+func (x *Stats) GetFta() int64 {
+	if x != nil {
+		return x.Fta
+	}
+	return 0
+}
+
+func (x *Stats) GetFtp() float64 {
+	if x != nil {
+		return x.Ftp
+	}
+	return 0
+}
+
+func (x *Stats) GetOreb() int64 {
+	if x != nil {
+		return x.Oreb
+	}
+	return 0
+}
+
+func (x *Stats) GetDreb() int64 {
+	if x != nil {
+		return x.Dreb
+	}
+	return 0
+}
+
+func (x *Stats) GetReb() int64 {
+	if x != nil {
+		return x.Reb
+	}
+	return 0
+}
+
+func (x *Stats) GetTeamReb() int64 {
+	if x != nil {
+		return x.TeamReb
+	}
+	return 0
+}
+
+func (x *Stats) GetAst() int64 {
+	if x != nil {
+		return x.Ast
+	}
+	return 0
+}
+
+func (x *Stats) GetStl() int64 {
+	if x != nil {
+		return x.Stl
+	}
+	return 0
+}
+
+func (x *Stats) GetBlk() int64 {
+	if x != nil {
+		return x.Blk
+	}
+	return 0
+}
+
+func (x *Stats) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *Stats) GetTeamTo() int64 {
+	if x != nil {
+		return x.TeamTo
+	}
+	return 0
+}
+
+func (x *Stats) GetPf() int64 {
+	if x != nil {
+		return x.Pf
+	}
+	return 0
+}
+
+func (x *Stats) GetFd() int64 {
+	if x != nil {
+		return x.Fd
+	}
+	return 0
+}
+
+func (x *Stats) GetPts() int64 {
+	if x != nil {
+		return x.Pts
+	}
+	return 0
+}
+
+func (x *Stats) GetPlusMinus() float64 {
+	if x != nil {
+		return x.PlusMinus
+	}
+	return 0
+}
+
+var File_nba_stats_v1_stats_proto protoreflect.FileDescriptor
+
+var file_nba_stats_v1_stats_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x6e, 0x62, 0x61, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x73,
+	0x74, 0x61, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x6e, 0x62, 0x61, 0x2e,
+	0x73, 0x74, 0x61, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x79, 0x0a, 0x14, 0x47, 0x65, 0x74,
+	0x53, 0x63, 0x6f, 0x72, 0x65, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x6c, 0x65, 0x61, 0x67, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x6e, 0x62, 0x61, 0x2e, 0x73, 0x74, 0x61, 0x74,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x65, 0x61, 0x67, 0x75, 0x65, 0x52, 0x06, 0x6c, 0x65, 0x61,
+	0x67, 0x75, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x65, 0x61, 0x67, 0x75, 0x65, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6c, 0x65, 0x61, 0x67, 0x75, 0x65,
+	0x4e, 0x61, 0x6d, 0x65, 0x22, 0x7c, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x42, 0x6f, 0x78, 0x73, 0x63,
+	0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x67, 0x61,
+	0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x61, 0x6d,
+	0x65, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x06, 0x6c, 0x65, 0x61, 0x67, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x6e, 0x62, 0x61, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x4c, 0x65, 0x61, 0x67, 0x75, 0x65, 0x52, 0x06, 0x6c, 0x65, 0x61, 0x67, 0x75,
+	0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x65, 0x61, 0x67, 0x75, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6c, 0x65, 0x61, 0x67, 0x75, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x22, 0xb1, 0x01, 0x0a, 0x10, 0x57, 0x61, 0x74, 0x63, 0x68, 0x47, 0x61, 0x6d, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64,
+	0x12, 0x2c, 0x0a, 0x06, 0x6c, 0x65, 0x61, 0x67, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x14, 0x2e, 0x6e, 0x62, 0x61, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x65, 0x61, 0x67, 0x75, 0x65, 0x52, 0x06, 0x6c, 0x65, 0x61, 0x67, 0x75, 0x65, 0x12, 0x35,
+	0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x65, 0x61, 0x67, 0x75, 0x65, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6c, 0x65, 0x61, 0x67,
+	0x75, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x4a, 0x0a, 0x0a, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x62,
+	0x6f, 0x61, 0x72, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x67, 0x61, 0x6d, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6e, 0x62, 0x61, 0x2e, 0x73, 0x74,
+	0x61, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x05, 0x67, 0x61, 0x6d,
+	0x65, 0x73, 0x22, 0xe3, 0x01, 0x0a, 0x04, 0x47, 0x61, 0x6d, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x30, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x6e, 0x62,
+	0x61, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x37, 0x0a,
+	0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x73, 0x41, 0x74, 0x12, 0x2f, 0x0a, 0x09, 0x68, 0x6f, 0x6d, 0x65, 0x5f, 0x74,
+	0x65, 0x61, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6e, 0x62, 0x61, 0x2e,
+	0x73, 0x74, 0x61, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x61, 0x6d, 0x52, 0x08, 0x68,
+	0x6f, 0x6d, 0x65, 0x54, 0x65, 0x61, 0x6d, 0x12, 0x2f, 0x0a, 0x09, 0x61, 0x77, 0x61, 0x79, 0x5f,
+	0x74, 0x65, 0x61, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6e, 0x62, 0x61,
+	0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x61, 0x6d, 0x52, 0x08,
+	0x61, 0x77, 0x61, 0x79, 0x54, 0x65, 0x61, 0x6d, 0x22, 0xb7, 0x01, 0x0a, 0x08, 0x42, 0x6f, 0x78,
+	0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x30,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18,
+	0x2e, 0x6e, 0x62, 0x61, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x61,
+	0x6d, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x2f, 0x0a, 0x09, 0x68, 0x6f, 0x6d, 0x65, 0x5f, 0x74, 0x65, 0x61, 0x6d, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6e, 0x62, 0x61, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x54, 0x65, 0x61, 0x6d, 0x52, 0x08, 0x68, 0x6f, 0x6d, 0x65, 0x54, 0x65, 0x61,
+	0x6d, 0x12, 0x2f, 0x0a, 0x09, 0x61, 0x77, 0x61, 0x79, 0x5f, 0x74, 0x65, 0x61, 0x6d, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6e, 0x62, 0x61, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x61, 0x6d, 0x52, 0x08, 0x61, 0x77, 0x61, 0x79, 0x54, 0x65,
+	0x61, 0x6d, 0x22, 0x9f, 0x01, 0x0a, 0x04, 0x54, 0x65, 0x61, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x74, 0x72, 0x69, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x74, 0x72, 0x69, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x29, 0x0a, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6e, 0x62, 0x61, 0x2e, 0x73,
+	0x74, 0x61, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6e, 0x62, 0x61, 0x2e, 0x73, 0x74, 0x61, 0x74,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x07, 0x70, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x73, 0x22, 0x8b, 0x01, 0x0a, 0x06, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12,
+	0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x72, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70,
+	0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70,
+	0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6e, 0x62, 0x61, 0x2e, 0x73, 0x74, 0x61,
+	0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x73, 0x22, 0xe9, 0x03, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x66, 0x67, 0x6d, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x03, 0x66, 0x67, 0x6d, 0x12, 0x10, 0x0a, 0x03, 0x66, 0x67, 0x61, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x66, 0x67, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x66, 0x67,
+	0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x66, 0x67, 0x70, 0x12, 0x1b, 0x0a, 0x09,
+	0x74, 0x68, 0x72, 0x65, 0x65, 0x5f, 0x66, 0x67, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x74, 0x68, 0x72, 0x65, 0x65, 0x46, 0x67, 0x6d, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x68, 0x72,
+	0x65, 0x65, 0x5f, 0x66, 0x67, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x68,
+	0x72, 0x65, 0x65, 0x46, 0x67, 0x61, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65, 0x65, 0x5f,
+	0x66, 0x67, 0x70, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x74, 0x68, 0x72, 0x65, 0x65,
+	0x46, 0x67, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x66, 0x74, 0x6d, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x03, 0x66, 0x74, 0x6d, 0x12, 0x10, 0x0a, 0x03, 0x66, 0x74, 0x61, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x03, 0x66, 0x74, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x66, 0x74, 0x70, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x66, 0x74, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x6f, 0x72, 0x65,
+	0x62, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x6f, 0x72, 0x65, 0x62, 0x12, 0x12, 0x0a,
+	0x04, 0x64, 0x72, 0x65, 0x62, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x64, 0x72, 0x65,
+	0x62, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x65, 0x62, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03,
+	0x72, 0x65, 0x62, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x65, 0x61, 0x6d, 0x5f, 0x72, 0x65, 0x62, 0x18,
+	0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x74, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x62, 0x12, 0x10,
+	0x0a, 0x03, 0x61, 0x73, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x61, 0x73, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x73, 0x74, 0x6c, 0x18, 0x10, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73,
+	0x74, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x6c, 0x6b, 0x18, 0x11, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x03, 0x62, 0x6c, 0x6b, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x12, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x02, 0x74, 0x6f, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x65, 0x61, 0x6d, 0x5f, 0x74, 0x6f, 0x18,
+	0x13, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x74, 0x65, 0x61, 0x6d, 0x54, 0x6f, 0x12, 0x0e, 0x0a,
+	0x02, 0x70, 0x66, 0x18, 0x14, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x70, 0x66, 0x12, 0x0e, 0x0a,
+	0x02, 0x66, 0x64, 0x18, 0x15, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x66, 0x64, 0x12, 0x10, 0x0a,
+	0x03, 0x70, 0x74, 0x73, 0x18, 0x16, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x70, 0x74, 0x73, 0x12,
+	0x1d, 0x0a, 0x0a, 0x70, 0x6c, 0x75, 0x73, 0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x73, 0x18, 0x17, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x09, 0x70, 0x6c, 0x75, 0x73, 0x4d, 0x69, 0x6e, 0x75, 0x73, 0x2a, 0x70,
+	0x0a, 0x06, 0x4c, 0x65, 0x61, 0x67, 0x75, 0x65, 0x12, 0x16, 0x0a, 0x12, 0x4c, 0x45, 0x41, 0x47,
+	0x55, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x0e, 0x0a, 0x0a, 0x4c, 0x45, 0x41, 0x47, 0x55, 0x45, 0x5f, 0x4e, 0x42, 0x41, 0x10, 0x01,
+	0x12, 0x0f, 0x0a, 0x0b, 0x4c, 0x45, 0x41, 0x47, 0x55, 0x45, 0x5f, 0x57, 0x4e, 0x42, 0x41, 0x10,
+	0x02, 0x12, 0x13, 0x0a, 0x0f, 0x4c, 0x45, 0x41, 0x47, 0x55, 0x45, 0x5f, 0x47, 0x5f, 0x4c, 0x45,
+	0x41, 0x47, 0x55, 0x45, 0x10, 0x03, 0x12, 0x18, 0x0a, 0x14, 0x4c, 0x45, 0x41, 0x47, 0x55, 0x45,
+	0x5f, 0x53, 0x55, 0x4d, 0x4d, 0x45, 0x52, 0x5f, 0x4c, 0x45, 0x41, 0x47, 0x55, 0x45, 0x10, 0x04,
+	0x2a, 0x71, 0x0a, 0x0a, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1b,
+	0x0a, 0x17, 0x47, 0x41, 0x4d, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x47,
+	0x41, 0x4d, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x53, 0x43, 0x48, 0x45, 0x44,
+	0x55, 0x4c, 0x45, 0x44, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x47, 0x41, 0x4d, 0x45, 0x5f, 0x53,
+	0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x4c, 0x49, 0x56, 0x45, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11,
+	0x47, 0x41, 0x4d, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x46, 0x49, 0x4e, 0x41,
+	0x4c, 0x10, 0x03, 0x32, 0xed, 0x01, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x74, 0x73, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x4d, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x53, 0x63, 0x6f, 0x72, 0x65,
+	0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x22, 0x2e, 0x6e, 0x62, 0x61, 0x2e, 0x73, 0x74, 0x61, 0x74,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x62, 0x6f, 0x61,
+	0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6e, 0x62, 0x61, 0x2e,
+	0x73, 0x74, 0x61, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x62, 0x6f,
+	0x61, 0x72, 0x64, 0x12, 0x47, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x42, 0x6f, 0x78, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x12, 0x20, 0x2e, 0x6e, 0x62, 0x61, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6f, 0x78, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x6e, 0x62, 0x61, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f, 0x78, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x45, 0x0a, 0x09,
+	0x57, 0x61, 0x74, 0x63, 0x68, 0x47, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x2e, 0x6e, 0x62, 0x61, 0x2e,
+	0x73, 0x74, 0x61, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x47, 0x61,
+	0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x6e, 0x62, 0x61, 0x2e,
+	0x73, 0x74, 0x61, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f, 0x78, 0x73, 0x63, 0x6f, 0x72,
+	0x65, 0x30, 0x01, 0x42, 0x41, 0x5a, 0x3f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x70, 0x65, 0x64, 0x72, 0x6f, 0x2d, 0x6d, 0x65, 0x61, 0x6c, 0x68, 0x61, 0x2f, 0x6e,
+	0x62, 0x61, 0x2d, 0x73, 0x74, 0x61, 0x74, 0x73, 0x2d, 0x61, 0x70, 0x69, 0x2f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x61, 0x70, 0x70, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x73,
+	0x74, 0x61, 0x74, 0x73, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_nba_stats_v1_stats_proto_rawDescOnce sync.Once
+	file_nba_stats_v1_stats_proto_rawDescData = file_nba_stats_v1_stats_proto_rawDesc
+)
+
+func file_nba_stats_v1_stats_proto_rawDescGZIP() []byte {
+	file_nba_stats_v1_stats_proto_rawDescOnce.Do(func() {
+		file_nba_stats_v1_stats_proto_rawDescData = protoimpl.X.CompressGZIP(file_nba_stats_v1_stats_proto_rawDescData)
+	})
+	return file_nba_stats_v1_stats_proto_rawDescData
+}
+
+var file_nba_stats_v1_stats_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_nba_stats_v1_stats_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_nba_stats_v1_stats_proto_goTypes = []any{
+	(League)(0),                   // 0: nba.stats.v1.League
+	(GameStatus)(0),               // 1: nba.stats.v1.GameStatus
+	(*GetScoreboardRequest)(nil),  // 2: nba.stats.v1.GetScoreboardRequest
+	(*GetBoxscoreRequest)(nil),    // 3: nba.stats.v1.GetBoxscoreRequest
+	(*WatchGameRequest)(nil),      // 4: nba.stats.v1.WatchGameRequest
+	(*Scoreboard)(nil),            // 5: nba.stats.v1.Scoreboard
+	(*Game)(nil),                  // 6: nba.stats.v1.Game
+	(*Boxscore)(nil),              // 7: nba.stats.v1.Boxscore
+	(*Team)(nil),                  // 8: nba.stats.v1.Team
+	(*Player)(nil),                // 9: nba.stats.v1.Player
+	(*Stats)(nil),                 // 10: nba.stats.v1.Stats
+	(*durationpb.Duration)(nil),   // 11: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil), // 12: google.protobuf.Timestamp
+}
+var file_nba_stats_v1_stats_proto_depIdxs = []int32{
+	0,  // 0: nba.stats.v1.GetScoreboardRequest.league:type_name -> nba.stats.v1.League
+	0,  // 1: nba.stats.v1.GetBoxscoreRequest.league:type_name -> nba.stats.v1.League
+	0,  // 2: nba.stats.v1.WatchGameRequest.league:type_name -> nba.stats.v1.League
+	11, // 3: nba.stats.v1.WatchGameRequest.interval:type_name -> google.protobuf.Duration
+	6,  // 4: nba.stats.v1.Scoreboard.games:type_name -> nba.stats.v1.Game
+	1,  // 5: nba.stats.v1.Game.status:type_name -> nba.stats.v1.GameStatus
+	12, // 6: nba.stats.v1.Game.starts_at:type_name -> google.protobuf.Timestamp
+	8,  // 7: nba.stats.v1.Game.home_team:type_name -> nba.stats.v1.Team
+	8,  // 8: nba.stats.v1.Game.away_team:type_name -> nba.stats.v1.Team
+	1,  // 9: nba.stats.v1.Boxscore.status:type_name -> nba.stats.v1.GameStatus
+	8,  // 10: nba.stats.v1.Boxscore.home_team:type_name -> nba.stats.v1.Team
+	8,  // 11: nba.stats.v1.Boxscore.away_team:type_name -> nba.stats.v1.Team
+	10, // 12: nba.stats.v1.Team.stats:type_name -> nba.stats.v1.Stats
+	9,  // 13: nba.stats.v1.Team.players:type_name -> nba.stats.v1.Player
+	10, // 14: nba.stats.v1.Player.stats:type_name -> nba.stats.v1.Stats
+	2,  // 15: nba.stats.v1.StatsService.GetScoreboard:input_type -> nba.stats.v1.GetScoreboardRequest
+	3,  // 16: nba.stats.v1.StatsService.GetBoxscore:input_type -> nba.stats.v1.GetBoxscoreRequest
+	4,  // 17: nba.stats.v1.StatsService.WatchGame:input_type -> nba.stats.v1.WatchGameRequest
+	5,  // 18: nba.stats.v1.StatsService.GetScoreboard:output_type -> nba.stats.v1.Scoreboard
+	7,  // 19: nba.stats.v1.StatsService.GetBoxscore:output_type -> nba.stats.v1.Boxscore
+	7,  // 20: nba.stats.v1.StatsService.WatchGame:output_type -> nba.stats.v1.Boxscore
+	18, // [18:21] is the sub-list for method output_type
+	15, // [15:18] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_nba_stats_v1_stats_proto_init() }
+func file_nba_stats_v1_stats_proto_init() {
+	if File_nba_stats_v1_stats_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_nba_stats_v1_stats_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*GetScoreboardRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nba_stats_v1_stats_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*GetBoxscoreRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nba_stats_v1_stats_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*WatchGameRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nba_stats_v1_stats_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*Scoreboard); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nba_stats_v1_stats_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*Game); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nba_stats_v1_stats_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*Boxscore); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nba_stats_v1_stats_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*Team); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nba_stats_v1_stats_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*Player); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nba_stats_v1_stats_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*Stats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_nba_stats_v1_stats_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_nba_stats_v1_stats_proto_goTypes,
+		DependencyIndexes: file_nba_stats_v1_stats_proto_depIdxs,
+		EnumInfos:         file_nba_stats_v1_stats_proto_enumTypes,
+		MessageInfos:      file_nba_stats_v1_stats_proto_msgTypes,
+	}.Build()
+	File_nba_stats_v1_stats_proto = out.File
+	file_nba_stats_v1_stats_proto_rawDesc = nil
+	file_nba_stats_v1_stats_proto_goTypes = nil
+	file_nba_stats_v1_stats_proto_depIdxs = nil
+}
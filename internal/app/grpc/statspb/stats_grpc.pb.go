@@ -0,0 +1,228 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: nba/stats/v1/stats.proto
+
+package statspb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	StatsService_GetScoreboard_FullMethodName = "/nba.stats.v1.StatsService/GetScoreboard"
+	StatsService_GetBoxscore_FullMethodName   = "/nba.stats.v1.StatsService/GetBoxscore"
+	StatsService_WatchGame_FullMethodName     = "/nba.stats.v1.StatsService/WatchGame"
+)
+
+// StatsServiceClient is the client API for StatsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// StatsService mirrors the /stats REST endpoints.
+type StatsServiceClient interface {
+	// GetScoreboard returns the games of a day.
+	GetScoreboard(ctx context.Context, in *GetScoreboardRequest, opts ...grpc.CallOption) (*Scoreboard, error)
+	// GetBoxscore returns the box score of a game.
+	GetBoxscore(ctx context.Context, in *GetBoxscoreRequest, opts ...grpc.CallOption) (*Boxscore, error)
+	// WatchGame streams the box score of a game every time it changes, until
+	// the game is final or the client goes away. Unknown games fail with
+	// NOT_FOUND, and every poll takes a token of the client rate limit.
+	WatchGame(ctx context.Context, in *WatchGameRequest, opts ...grpc.CallOption) (StatsService_WatchGameClient, error)
+}
+
+type statsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStatsServiceClient(cc grpc.ClientConnInterface) StatsServiceClient {
+	return &statsServiceClient{cc}
+}
+
+func (c *statsServiceClient) GetScoreboard(ctx context.Context, in *GetScoreboardRequest, opts ...grpc.CallOption) (*Scoreboard, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Scoreboard)
+	err := c.cc.Invoke(ctx, StatsService_GetScoreboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statsServiceClient) GetBoxscore(ctx context.Context, in *GetBoxscoreRequest, opts ...grpc.CallOption) (*Boxscore, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Boxscore)
+	err := c.cc.Invoke(ctx, StatsService_GetBoxscore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statsServiceClient) WatchGame(ctx context.Context, in *WatchGameRequest, opts ...grpc.CallOption) (StatsService_WatchGameClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StatsService_ServiceDesc.Streams[0], StatsService_WatchGame_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &statsServiceWatchGameClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StatsService_WatchGameClient interface {
+	Recv() (*Boxscore, error)
+	grpc.ClientStream
+}
+
+type statsServiceWatchGameClient struct {
+	grpc.ClientStream
+}
+
+func (x *statsServiceWatchGameClient) Recv() (*Boxscore, error) {
+	m := new(Boxscore)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// StatsServiceServer is the server API for StatsService service.
+// All implementations must embed UnimplementedStatsServiceServer
+// for forward compatibility
+//
+// StatsService mirrors the /stats REST endpoints.
+type StatsServiceServer interface {
+	// GetScoreboard returns the games of a day.
+	GetScoreboard(context.Context, *GetScoreboardRequest) (*Scoreboard, error)
+	// GetBoxscore returns the box score of a game.
+	GetBoxscore(context.Context, *GetBoxscoreRequest) (*Boxscore, error)
+	// WatchGame streams the box score of a game every time it changes, until
+	// the game is final or the client goes away. Unknown games fail with
+	// NOT_FOUND, and every poll takes a token of the client rate limit.
+	WatchGame(*WatchGameRequest, StatsService_WatchGameServer) error
+	mustEmbedUnimplementedStatsServiceServer()
+}
+
+// UnimplementedStatsServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedStatsServiceServer struct {
+}
+
+func (UnimplementedStatsServiceServer) GetScoreboard(context.Context, *GetScoreboardRequest) (*Scoreboard, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScoreboard not implemented")
+}
+func (UnimplementedStatsServiceServer) GetBoxscore(context.Context, *GetBoxscoreRequest) (*Boxscore, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBoxscore not implemented")
+}
+func (UnimplementedStatsServiceServer) WatchGame(*WatchGameRequest, StatsService_WatchGameServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchGame not implemented")
+}
+func (UnimplementedStatsServiceServer) mustEmbedUnimplementedStatsServiceServer() {}
+
+// UnsafeStatsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StatsServiceServer will
+// result in compilation errors.
+type UnsafeStatsServiceServer interface {
+	mustEmbedUnimplementedStatsServiceServer()
+}
+
+func RegisterStatsServiceServer(s grpc.ServiceRegistrar, srv StatsServiceServer) {
+	s.RegisterService(&StatsService_ServiceDesc, srv)
+}
+
+func _StatsService_GetScoreboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScoreboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatsServiceServer).GetScoreboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatsService_GetScoreboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatsServiceServer).GetScoreboard(ctx, req.(*GetScoreboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatsService_GetBoxscore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBoxscoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatsServiceServer).GetBoxscore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatsService_GetBoxscore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatsServiceServer).GetBoxscore(ctx, req.(*GetBoxscoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatsService_WatchGame_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchGameRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StatsServiceServer).WatchGame(m, &statsServiceWatchGameServer{ServerStream: stream})
+}
+
+type StatsService_WatchGameServer interface {
+	Send(*Boxscore) error
+	grpc.ServerStream
+}
+
+type statsServiceWatchGameServer struct {
+	grpc.ServerStream
+}
+
+func (x *statsServiceWatchGameServer) Send(m *Boxscore) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// StatsService_ServiceDesc is the grpc.ServiceDesc for StatsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StatsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "nba.stats.v1.StatsService",
+	HandlerType: (*StatsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetScoreboard",
+			Handler:    _StatsService_GetScoreboard_Handler,
+		},
+		{
+			MethodName: "GetBoxscore",
+			Handler:    _StatsService_GetBoxscore_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchGame",
+			Handler:       _StatsService_WatchGame_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "nba/stats/v1/stats.proto",
+}
@@ -0,0 +1,4 @@
+package graphql
+
+// Selections exposes the selection count the queries are limited by.
+func Selections(query string) int { return selections(query) }
@@ -0,0 +1,96 @@
+// Package graphql exposes the stats domain as a GraphQL schema.
+package graphql
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	gql "github.com/graph-gophers/graphql-go"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+)
+
+//go:embed schema.graphql
+var schema string
+
+type (
+	// Handler serves GraphQL queries over GET and POST
+	Handler struct {
+		p      stats.Provider
+		schema *gql.Schema
+	}
+
+	request struct {
+		Query         string                 `json:"query"`
+		OperationName string                 `json:"operationName"`
+		Variables     map[string]interface{} `json:"variables"`
+	}
+)
+
+// NewHandler creates a new GraphQL handler serving the leagues of the
+// registry from the provider
+func NewHandler(p stats.Provider, leagues *nba.Registry) *Handler {
+	return &Handler{
+		p:      p,
+		schema: gql.MustParseSchema(schema, &resolver{leagues: leagues}, gql.MaxDepth(10)),
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req request
+
+	switch r.Method {
+	case http.MethodGet:
+		req.Query = r.URL.Query().Get("query")
+		req.OperationName = r.URL.Query().Get("operationName")
+
+		if v := r.URL.Query().Get("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				http.Error(w, "invalid variables", http.StatusBadRequest)
+
+				return
+			}
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes)).Decode(&req); err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+
+				return
+			}
+
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+
+			return
+		}
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	switch {
+	case req.Query == "":
+		http.Error(w, "missing query", http.StatusBadRequest)
+
+		return
+	case len(req.Query) > maxQueryLength:
+		http.Error(w, fmt.Sprintf("query longer than %d bytes", maxQueryLength), http.StatusBadRequest)
+
+		return
+	case selections(req.Query) > maxSelections:
+		http.Error(w, fmt.Sprintf("query selects more than %d fields", maxSelections), http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := withLoader(r.Context(), newLoader(h.p))
+	res := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res) //nolint: errcheck
+}
@@ -0,0 +1,191 @@
+package graphql_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba/nbatest"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/http/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var errFailed = errors.New("failed")
+
+type response struct {
+	Data   json.RawMessage   `json:"data"`
+	Errors []json.RawMessage `json:"errors"`
+}
+
+type HandlerTestSuite struct {
+	suite.Suite
+
+	pm *stats.ProviderMock
+	h  *graphql.Handler
+}
+
+func (s *HandlerTestSuite) SetupTest() {
+	s.pm = new(stats.ProviderMock)
+	s.h = graphql.NewHandler(s.pm, nbatest.Leagues("https://nba.test"))
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(HandlerTestSuite))
+}
+
+func (s *HandlerTestSuite) TestScoreboardWithNestedStats() {
+	sb := stats.Scoreboard{
+		Date: nba.GameDate(time.Date(2022, 10, 18, 0, 0, 0, 0, time.UTC)),
+		Games: []stats.Game{
+			{ID: "0022200001", HomeTeam: stats.Team{ID: 1, Tricode: "BOS"}, AwayTeam: stats.Team{ID: 2, Tricode: "PHI"}},
+			{ID: "0022200002", HomeTeam: stats.Team{ID: 3, Tricode: "GSW"}, AwayTeam: stats.Team{ID: 4, Tricode: "LAL"}},
+		},
+	}
+
+	s.pm.On("GetScoreboard", mock.Anything, nba.GetScoreboardCommand{Date: "2022-10-18", LeagueID: nba.NBA}).Return(sb, nil).Once()
+
+	for i, g := range sb.Games {
+		b := stats.Boxscore{
+			GameID:   g.ID,
+			HomeTeam: stats.Team{ID: g.HomeTeam.ID, Stats: stats.Stats{PT: int64(100 + i)}},
+			AwayTeam: stats.Team{ID: g.AwayTeam.ID, Stats: stats.Stats{PT: int64(90 + i)}},
+		}
+
+		s.pm.On("GetBoxscore", mock.Anything, nba.GetBoxscoreCommand{GameID: g.ID, LeagueID: nba.NBA}).Return(b, nil).Once()
+	}
+
+	res := s.post(`{
+		scoreboard(date: "2022-10-18") {
+			date
+			games {
+				id
+				homeTeam { tricode stats { pts } }
+				awayTeam { tricode stats { pts } }
+				boxscore { gameId }
+			}
+		}
+	}`)
+
+	s.Empty(res.Errors)
+	s.JSONEq(`{
+		"scoreboard": {
+			"date": "2022-10-18",
+			"games": [
+				{"id": "0022200001", "homeTeam": {"tricode": "BOS", "stats": {"pts": 100}}, "awayTeam": {"tricode": "PHI", "stats": {"pts": 90}}, "boxscore": {"gameId": "0022200001"}},
+				{"id": "0022200002", "homeTeam": {"tricode": "GSW", "stats": {"pts": 101}}, "awayTeam": {"tricode": "LAL", "stats": {"pts": 91}}, "boxscore": {"gameId": "0022200002"}}
+			]
+		}
+	}`, string(res.Data))
+
+	// One upstream call per game, however many fields need its box score.
+	s.pm.AssertNumberOfCalls(s.T(), "GetBoxscore", 2)
+}
+
+func (s *HandlerTestSuite) TestBoxscore() {
+	cmd := nba.GetBoxscoreCommand{GameID: "1022200001", LeagueID: nba.WNBA}
+
+	s.pm.On("GetBoxscore", mock.Anything, cmd).Return(stats.Boxscore{
+		GameID: cmd.GameID,
+		HomeTeam: stats.Team{
+			Players: []stats.Player{{FirstName: "A'ja", LastName: "Wilson", Stats: stats.Stats{ThreeFGM: 2}}},
+		},
+	}, nil)
+
+	for _, league := range []string{`"wnba"`, `"WNBA"`, "null"} {
+		q := url.Values{"query": {`query($id: ID!, $league: String) { boxscore(gameId: $id, league: $league) { homeTeam { players { lastName stats { threeFgm } } } } }`}, "variables": {`{"id":"1022200001","league":` + league + `}`}}
+
+		rec := httptest.NewRecorder()
+		s.h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/graphql?"+q.Encode(), nil))
+
+		// Without a league, it is the one of the game id.
+		s.Equal(http.StatusOK, rec.Code, league)
+		s.JSONEq(`{"data":{"boxscore":{"homeTeam":{"players":[{"lastName":"Wilson","stats":{"threeFgm":2}}]}}}}`, rec.Body.String(), league)
+	}
+}
+
+func (s *HandlerTestSuite) TestUnknownLeague() {
+	res := s.post(`{ scoreboard(league: "euroleague") { date } }`)
+
+	s.Len(res.Errors, 1)
+	s.pm.AssertNotCalled(s.T(), "GetScoreboard", mock.Anything, mock.Anything)
+}
+
+func (s *HandlerTestSuite) TestProviderError() {
+	s.pm.On("GetBoxscore", mock.Anything, mock.Anything).Return(stats.Boxscore{}, errFailed)
+
+	res := s.post(`{ boxscore(gameId: "0022200001") { gameId } }`)
+
+	s.Len(res.Errors, 1)
+}
+
+func (s *HandlerTestSuite) TestBadRequest() {
+	rec := httptest.NewRecorder()
+	s.h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewBufferString(`{}`)))
+
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func (s *HandlerTestSuite) TestLimits() {
+	aliases := make([]string, 501)
+	for i := range aliases {
+		aliases[i] = fmt.Sprintf("b%d: boxscore(gameId: \"0022200001\") { gameId }", i)
+	}
+
+	for scenario, tt := range map[string]struct {
+		body    string
+		expCode int
+	}{
+		"body too large":  {body: `{"query":"` + strings.Repeat(" ", 64<<10) + `{ scoreboard { date } }"}`, expCode: http.StatusRequestEntityTooLarge},
+		"query too long":  {body: `{"query":"{ scoreboard { date } }` + strings.Repeat(" ", 8<<10) + `"}`, expCode: http.StatusBadRequest},
+		"too many fields": {body: `{"query":"{ ` + strings.Join(aliases, " ") + ` }"}`, expCode: http.StatusBadRequest},
+	} {
+		rec := httptest.NewRecorder()
+		s.h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(tt.body)))
+
+		s.Equal(tt.expCode, rec.Code, scenario)
+	}
+
+	s.pm.AssertNotCalled(s.T(), "GetBoxscore", mock.Anything, mock.Anything)
+}
+
+func TestSelections(t *testing.T) {
+	t.Parallel()
+
+	for query, exp := range map[string]int{
+		`{ scoreboard { date } }`: 2,
+		`{ a: boxscore(gameId: "1") { gameId } b: boxscore(gameId: "2") { gameId } }`:                      4,
+		`query Q($d: String = "{ x y }") { scoreboard(date: $d) @skip(if: false) { games { id } } }`:       3,
+		`{ scoreboard { games { ...G ... on Game { id } } } } fragment G on Game { id homeTeam { name } }`: 7,
+		"{ scoreboard { # date games\n date } }":                                                           2,
+	} {
+		assert.Equal(t, exp, graphql.Selections(query), query)
+	}
+}
+
+func (s *HandlerTestSuite) post(query string) response {
+	body, err := json.Marshal(map[string]string{"query": query})
+	s.Require().NoError(err)
+
+	rec := httptest.NewRecorder()
+	s.h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body)))
+
+	var res response
+
+	s.Require().Equal(http.StatusOK, rec.Code)
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &res))
+
+	return res
+}
@@ -0,0 +1,124 @@
+package graphql
+
+const (
+	// maxBodyBytes bounds the POST body, variables included
+	maxBodyBytes = 64 << 10
+
+	// maxQueryLength bounds the query document, in bytes
+	maxQueryLength = 8 << 10
+
+	// maxSelections bounds the fields and fragment spreads of a query, so
+	// aliases can't ask for the same field thousands of times
+	maxSelections = 500
+)
+
+// selections counts the fields and fragment spreads selected by the query
+// document. Names in arguments, directives and type conditions are not
+// selections, and an alias is counted once with its field.
+func selections(query string) int {
+	var (
+		n              int
+		braces, parens int
+		spread         bool
+	)
+
+	for i := 0; i < len(query); i++ {
+		switch c := query[i]; {
+		case c == '#':
+			for i < len(query) && query[i] != '\n' {
+				i++
+			}
+		case c == '"':
+			i = skipString(query, i)
+		case c == '{':
+			braces++
+		case c == '}':
+			braces--
+		case c == '(':
+			parens++
+		case c == ')':
+			parens--
+		case c == '$' || c == '@':
+			// Variables and directives are not selections.
+			i = skipName(query, i+1) - 1
+		case c == '.' && i+2 < len(query) && query[i+1] == '.' && query[i+2] == '.':
+			spread = true
+			i += 2
+		case isNameStart(c):
+			end := skipName(query, i)
+			name := query[i:end]
+			i = end - 1
+
+			if braces == 0 || parens > 0 {
+				spread = false
+
+				continue
+			}
+
+			switch {
+			case spread && name == "on":
+				// An inline fragment, only its fields are selections.
+				i = skipName(query, skipSpace(query, end)) - 1
+			case isAlias(query, end):
+			default:
+				n++
+			}
+
+			spread = false
+		}
+	}
+
+	return n
+}
+
+// isAlias reports whether the name ending at i is followed by a colon
+func isAlias(query string, i int) bool {
+	i = skipSpace(query, i)
+
+	return i < len(query) && query[i] == ':'
+}
+
+func skipSpace(query string, i int) int {
+	for i < len(query) && (query[i] == ' ' || query[i] == '\t' || query[i] == '\n' || query[i] == '\r' || query[i] == ',') {
+		i++
+	}
+
+	return i
+}
+
+func skipName(query string, i int) int {
+	for i < len(query) && (isNameStart(query[i]) || query[i] >= '0' && query[i] <= '9') {
+		i++
+	}
+
+	return i
+}
+
+// skipString returns the index of the closing quote of the string or block
+// string starting at i
+func skipString(query string, i int) int {
+	if len(query) >= i+3 && query[i:i+3] == `"""` {
+		for i += 3; i+2 < len(query); i++ {
+			if query[i:i+3] == `"""` {
+				return i + 2
+			}
+		}
+
+		return len(query)
+	}
+
+	for i++; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+
+	return i
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
@@ -0,0 +1,107 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+)
+
+// maxUpstreamCalls caps the concurrent provider calls of a single query
+const maxUpstreamCalls = 4
+
+type (
+	loaderKey struct{}
+
+	// loader deduplicates and caches provider calls for the lifetime of a
+	// request, so resolving the same game from several fields only reaches the
+	// upstream once.
+	loader struct {
+		p   stats.Provider
+		sem chan struct{}
+
+		scoreboards memo[nba.GetScoreboardCommand, stats.Scoreboard]
+		boxscores   memo[nba.GetBoxscoreCommand, stats.Boxscore]
+	}
+
+	memo[K comparable, V any] struct {
+		mu    sync.Mutex
+		calls map[K]*call[V]
+	}
+
+	call[V any] struct {
+		done chan struct{}
+		v    V
+		err  error
+	}
+)
+
+func newLoader(p stats.Provider) *loader {
+	return &loader{p: p, sem: make(chan struct{}, maxUpstreamCalls)}
+}
+
+func withLoader(ctx context.Context, l *loader) context.Context {
+	return context.WithValue(ctx, loaderKey{}, l)
+}
+
+func loaderFrom(ctx context.Context) *loader {
+	return ctx.Value(loaderKey{}).(*loader)
+}
+
+func (l *loader) scoreboard(ctx context.Context, cmd nba.GetScoreboardCommand) (stats.Scoreboard, error) {
+	return l.scoreboards.load(ctx, cmd, func() (stats.Scoreboard, error) {
+		return throttle(ctx, l.sem, func() (stats.Scoreboard, error) { return l.p.GetScoreboard(ctx, cmd) })
+	})
+}
+
+func (l *loader) boxscore(ctx context.Context, cmd nba.GetBoxscoreCommand) (stats.Boxscore, error) {
+	return l.boxscores.load(ctx, cmd, func() (stats.Boxscore, error) {
+		return throttle(ctx, l.sem, func() (stats.Boxscore, error) { return l.p.GetBoxscore(ctx, cmd) })
+	})
+}
+
+// load runs fn once per key. Concurrent callers of the same key wait for the
+// first one and share its result.
+func (m *memo[K, V]) load(ctx context.Context, key K, fn func() (V, error)) (V, error) {
+	m.mu.Lock()
+
+	if m.calls == nil {
+		m.calls = make(map[K]*call[V])
+	}
+
+	c, ok := m.calls[key]
+	if !ok {
+		c = &call[V]{done: make(chan struct{})}
+		m.calls[key] = c
+	}
+
+	m.mu.Unlock()
+
+	if !ok {
+		c.v, c.err = fn()
+		close(c.done)
+	}
+
+	select {
+	case <-c.done:
+		return c.v, c.err
+	case <-ctx.Done():
+		var zero V
+
+		return zero, ctx.Err()
+	}
+}
+
+func throttle[V any](ctx context.Context, sem chan struct{}, fn func() (V, error)) (V, error) {
+	select {
+	case sem <- struct{}{}:
+		defer func() { <-sem }()
+
+		return fn()
+	case <-ctx.Done():
+		var zero V
+
+		return zero, ctx.Err()
+	}
+}
@@ -0,0 +1,243 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	gql "github.com/graph-gophers/graphql-go"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+)
+
+type (
+	resolver struct {
+		leagues *nba.Registry
+	}
+
+	scoreboardResolver struct {
+		sb     stats.Scoreboard
+		league nba.LeagueID
+	}
+
+	gameResolver struct {
+		g      stats.Game
+		league nba.LeagueID
+	}
+
+	boxscoreResolver struct {
+		b stats.Boxscore
+	}
+
+	// teamResolver resolves teams from both scoreboards and box scores. Only
+	// the latter carry stats and players, so scoreboard teams load them from
+	// the game box score.
+	teamResolver struct {
+		t    stats.Team
+		home bool
+		game *nba.GetBoxscoreCommand
+	}
+
+	playerResolver struct {
+		p stats.Player
+	}
+
+	statsResolver struct {
+		s stats.Stats
+	}
+)
+
+// league is the registered league with the name, in any case, or def without
+// one. Unlike REST, unknown names fail instead of falling back to the default
+// league.
+func (r *resolver) league(name *string, def nba.LeagueID) (nba.LeagueID, error) {
+	if name == nil {
+		return def, nil
+	}
+
+	n := strings.ToLower(*name)
+	if l := r.leagues.ByName(n); l.Name == n {
+		return l.ID, nil
+	}
+
+	return "", fmt.Errorf("unknown league %q", *name)
+}
+
+func (r *resolver) Scoreboard(ctx context.Context, args struct {
+	Date   *string
+	League *string
+}) (*scoreboardResolver, error) {
+	league, err := r.league(args.League, r.leagues.Default().ID)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := nba.GetScoreboardCommand{LeagueID: league}
+	if args.Date != nil {
+		cmd.Date = *args.Date
+	}
+
+	sb, err := loaderFrom(ctx).scoreboard(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return &scoreboardResolver{sb: sb, league: cmd.LeagueID}, nil
+}
+
+func (r *resolver) Boxscore(ctx context.Context, args struct {
+	GameID gql.ID
+	League *string
+}) (*boxscoreResolver, error) {
+	// Without a league, game ids tell which one they are from, like in REST.
+	def, ok := r.leagues.ByGameID(string(args.GameID))
+	if !ok {
+		def = r.leagues.Default()
+	}
+
+	league, err := r.league(args.League, def.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := nba.GetBoxscoreCommand{GameID: string(args.GameID), LeagueID: league}
+
+	b, err := loaderFrom(ctx).boxscore(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return &boxscoreResolver{b: b}, nil
+}
+
+func (r *scoreboardResolver) Date() string {
+	return time.Time(r.sb.Date).Format("2006-01-02")
+}
+
+func (r *scoreboardResolver) Games() []*gameResolver {
+	gg := make([]*gameResolver, len(r.sb.Games))
+	for i, g := range r.sb.Games {
+		gg[i] = &gameResolver{g: g, league: r.league}
+	}
+
+	return gg
+}
+
+func (r *gameResolver) ID() gql.ID { return gql.ID(r.g.ID) }
+
+func (r *gameResolver) StartsAt() string {
+	return time.Time(r.g.StartsAt).Format(time.RFC3339)
+}
+
+func (r *gameResolver) HomeTeam() *teamResolver {
+	return &teamResolver{t: r.g.HomeTeam, home: true, game: r.cmd()}
+}
+
+func (r *gameResolver) AwayTeam() *teamResolver {
+	return &teamResolver{t: r.g.AwayTeam, game: r.cmd()}
+}
+
+func (r *gameResolver) Boxscore(ctx context.Context) (*boxscoreResolver, error) {
+	b, err := loaderFrom(ctx).boxscore(ctx, *r.cmd())
+	if err != nil {
+		return nil, err
+	}
+
+	return &boxscoreResolver{b: b}, nil
+}
+
+func (r *gameResolver) cmd() *nba.GetBoxscoreCommand {
+	return &nba.GetBoxscoreCommand{GameID: r.g.ID, LeagueID: r.league}
+}
+
+func (r *boxscoreResolver) GameID() gql.ID { return gql.ID(r.b.GameID) }
+
+func (r *boxscoreResolver) HomeTeam() *teamResolver {
+	return &teamResolver{t: r.b.HomeTeam, home: true}
+}
+
+func (r *boxscoreResolver) AwayTeam() *teamResolver {
+	return &teamResolver{t: r.b.AwayTeam}
+}
+
+func (r *teamResolver) ID() gql.ID { return gql.ID(strconv.FormatInt(r.t.ID, 10)) }
+
+func (r *teamResolver) Name() string { return r.t.Name }
+
+func (r *teamResolver) Tricode() string { return r.t.Tricode }
+
+func (r *teamResolver) Stats(ctx context.Context) (*statsResolver, error) {
+	t, err := r.full(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &statsResolver{s: t.Stats}, nil
+}
+
+func (r *teamResolver) Players(ctx context.Context) ([]*playerResolver, error) {
+	t, err := r.full(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pp := make([]*playerResolver, len(t.Players))
+	for i, p := range t.Players {
+		pp[i] = &playerResolver{p: p}
+	}
+
+	return pp, nil
+}
+
+// full returns the team with its stats and players
+func (r *teamResolver) full(ctx context.Context) (stats.Team, error) {
+	if r.game == nil {
+		return r.t, nil
+	}
+
+	b, err := loaderFrom(ctx).boxscore(ctx, *r.game)
+	if err != nil {
+		return stats.Team{}, err
+	}
+
+	if r.home {
+		return b.HomeTeam, nil
+	}
+
+	return b.AwayTeam, nil
+}
+
+func (r *playerResolver) FirstName() string { return r.p.FirstName }
+
+func (r *playerResolver) LastName() string { return r.p.LastName }
+
+func (r *playerResolver) Position() string { return r.p.Position }
+
+func (r *playerResolver) Stats() *statsResolver { return &statsResolver{s: r.p.Stats} }
+
+func (r *statsResolver) Min() string            { return r.s.Minutes }
+func (r *statsResolver) SecondsPlayed() float64 { return r.s.SecondsPlayed }
+func (r *statsResolver) Fgm() int32             { return int32(r.s.FGM) }
+func (r *statsResolver) Fga() int32             { return int32(r.s.FGA) }
+func (r *statsResolver) Fgp() float64           { return r.s.FGP }
+func (r *statsResolver) ThreeFgm() int32        { return int32(r.s.ThreeFGM) }
+func (r *statsResolver) ThreeFga() int32        { return int32(r.s.ThreeFGA) }
+func (r *statsResolver) ThreeFgp() float64      { return r.s.ThreeFGP }
+func (r *statsResolver) Ftm() int32             { return int32(r.s.FTM) }
+func (r *statsResolver) Fta() int32             { return int32(r.s.FTA) }
+func (r *statsResolver) Ftp() float64           { return r.s.FTP }
+func (r *statsResolver) Oreb() int32            { return int32(r.s.RO) }
+func (r *statsResolver) Dreb() int32            { return int32(r.s.RD) }
+func (r *statsResolver) Reb() int32             { return int32(r.s.RT) }
+func (r *statsResolver) Rebt() int32            { return int32(r.s.RTeam) }
+func (r *statsResolver) Ast() int32             { return int32(r.s.AST) }
+func (r *statsResolver) Stl() int32             { return int32(r.s.STL) }
+func (r *statsResolver) Blk() int32             { return int32(r.s.BLK) }
+func (r *statsResolver) To() int32              { return int32(r.s.TO) }
+func (r *statsResolver) Tot() int32             { return int32(r.s.TOT) }
+func (r *statsResolver) Pf() int32              { return int32(r.s.FP) }
+func (r *statsResolver) Fd() int32              { return int32(r.s.FD) }
+func (r *statsResolver) Pts() int32             { return int32(r.s.PT) }
+func (r *statsResolver) PlusMinus() float64     { return r.s.PlusMinus }
@@ -0,0 +1,91 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/logging"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+type (
+	requestLogKey struct{}
+
+	// requestLog collects what inner handlers know about the request, for the
+	// access log line
+	requestLog struct {
+		client string
+	}
+)
+
+// accessLog assigns every request an id, taken from X-Request-ID when the
+// caller sets one, stores a logger tagged with it in the context and logs a
+// line once the request is served.
+func (a *API) accessLog(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := logging.RequestID(r.Header.Get(requestIDHeader))
+
+		w.Header().Set(requestIDHeader, id)
+
+		logger := a.logger.With("request_id", id)
+		if sc := trace.SpanContextFromContext(r.Context()); sc.HasTraceID() {
+			logger = logger.With("trace_id", sc.TraceID().String())
+		}
+
+		var (
+			start = time.Now()
+			rl    = &requestLog{}
+			ctx   = context.WithValue(logging.WithLogger(r.Context(), logger), requestLogKey{}, rl)
+			ww    = middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		)
+
+		next.ServeHTTP(ww, r.WithContext(ctx))
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		fields := []any{
+			"method", r.Method,
+			"route", routePattern(r),
+			"status", status,
+			"bytes", ww.BytesWritten(),
+			"latency", time.Since(start),
+		}
+
+		q := r.URL.Query()
+		for _, p := range []string{"league", "gameId", "date"} {
+			if v := q.Get(p); v != "" {
+				fields = append(fields, p, v)
+			}
+		}
+
+		if rl.client != "" {
+			fields = append(fields, "client", rl.client)
+		}
+
+		logger.Infow("request", fields...)
+	})
+}
+
+// setClient records the client of the request for the access log
+func setClient(ctx context.Context, client string) {
+	if rl, ok := ctx.Value(requestLogKey{}).(*requestLog); ok {
+		rl.client = client
+	}
+}
+
+// routePattern is the chi pattern the request matched, once it is served
+func routePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+		return rctx.RoutePattern()
+	}
+
+	return unmatchedRoute
+}
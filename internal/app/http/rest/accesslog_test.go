@@ -0,0 +1,116 @@
+// nolint
+package rest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/http/rest"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/ratelimit"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type AccessLogTestSuite struct {
+	suite.Suite
+
+	logs *observer.ObservedLogs
+	h    http.Handler
+}
+
+func (s *AccessLogTestSuite) SetupTest() {
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	rl, err := ratelimit.NewLimiter(ratelimit.Limits{
+		Anonymous: ratelimit.Tier{PerMinute: 60, Burst: 10},
+		Tiers:     map[string]ratelimit.Tier{"partner": {PerMinute: 600, Burst: 60}},
+		Keys:      []ratelimit.APIKey{{Key: "secret", Client: "celtics", Tier: "partner"}},
+	})
+	s.Require().NoError(err)
+
+	pm := new(stats.ProviderMock)
+	pm.On("GetBoxscore", mock.Anything, mock.Anything).Return(boxscore, nil)
+	pm.On("GetScoreboard", mock.Anything, mock.Anything).Return(stats.Scoreboard{}, errFailed)
+
+	s.logs = logs
+	s.h = rest.NewAPI(zap.New(core).Sugar(), pm, leagues, rest.WithRateLimiter(rl)).Routes()
+}
+
+func TestAccessLog(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(AccessLogTestSuite))
+}
+
+func (s *AccessLogTestSuite) get(path string, header map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+
+	w := httptest.NewRecorder()
+	s.h.ServeHTTP(w, req)
+
+	return w
+}
+
+func (s *AccessLogTestSuite) TestRequestID() {
+	w := s.get("/healthz", map[string]string{"X-Request-ID": "abc-123"})
+	s.Equal("abc-123", w.Header().Get("X-Request-ID"))
+
+	entries := s.logs.FilterMessage("request").All()
+	s.Require().Len(entries, 1)
+	s.Equal("abc-123", entries[0].ContextMap()["request_id"])
+}
+
+func (s *AccessLogTestSuite) TestGeneratedRequestID() {
+	w := s.get("/healthz", map[string]string{"X-Request-ID": "not valid\n"})
+
+	id := w.Header().Get("X-Request-ID")
+	s.Len(id, 32)
+
+	w = s.get("/healthz", nil)
+	s.Len(w.Header().Get("X-Request-ID"), 32)
+	s.NotEqual(id, w.Header().Get("X-Request-ID"))
+}
+
+func (s *AccessLogTestSuite) TestFields() {
+	w := s.get("/stats/boxscore?gameId=0022200001&league=00", map[string]string{"X-API-Key": "secret"})
+	s.Require().Equal(http.StatusOK, w.Code)
+
+	entries := s.logs.FilterMessage("request").All()
+	s.Require().Len(entries, 1)
+
+	fields := entries[0].ContextMap()
+	s.Equal(w.Header().Get("X-Request-ID"), fields["request_id"])
+	s.Equal(http.MethodGet, fields["method"])
+	s.Equal("/stats/boxscore", fields["route"])
+	s.EqualValues(http.StatusOK, fields["status"])
+	s.EqualValues(w.Body.Len(), fields["bytes"])
+	s.Contains(fields, "latency")
+	s.Equal("0022200001", fields["gameId"])
+	s.Equal("00", fields["league"])
+	s.Equal("key:celtics", fields["client"])
+}
+
+func (s *AccessLogTestSuite) TestAnonymousClient() {
+	s.get("/stats/boxscore?gameId=0022200001", nil)
+
+	entries := s.logs.FilterMessage("request").All()
+	s.Require().Len(entries, 1)
+	s.Equal("ip:192.0.2.1", entries[0].ContextMap()["client"])
+}
+
+func (s *AccessLogTestSuite) TestErrorsTaggedWithRequestID() {
+	w := s.get("/stats/scoreboard?date=2022-10-18", nil)
+	s.Equal(http.StatusInternalServerError, w.Code)
+
+	entries := s.logs.FilterMessage("failed to get scoreboard").All()
+	s.Require().Len(entries, 1)
+	s.Equal(w.Header().Get("X-Request-ID"), entries[0].ContextMap()["request_id"])
+}
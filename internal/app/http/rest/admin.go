@@ -0,0 +1,58 @@
+package rest
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/logging"
+	"go.uber.org/zap"
+)
+
+// admin guards the endpoints operating the API
+type admin struct {
+	token string
+	level zap.AtomicLevel
+}
+
+// WithLogLevel lets callers with the admin token read and change the log level
+// at /admin/log/level, and scrape /metrics. Without a token the admin
+// endpoints and /metrics answer 404.
+func WithLogLevel(level zap.AtomicLevel, token string) Option {
+	return func(a *API) { a.admin = &admin{token: token, level: level} }
+}
+
+// adminOnly only lets through requests with the admin bearer token
+func (a *API) adminOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", noStore)
+
+		if a.admin == nil || a.admin.token == "" {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(a.admin.token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+			http.Error(w, "invalid admin token", http.StatusUnauthorized)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// logLevel reads the level with GET, and changes it with PUT and a
+// {"level":"debug"} body.
+func (a *API) logLevel(w http.ResponseWriter, r *http.Request) {
+	before := a.admin.level.Level()
+
+	a.admin.level.ServeHTTP(w, r)
+
+	if after := a.admin.level.Level(); after != before {
+		logging.FromContext(r.Context()).Warnw("log level changed", "from", before.String(), "to", after.String())
+	}
+}
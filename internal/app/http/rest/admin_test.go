@@ -0,0 +1,95 @@
+package rest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/http/rest"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type AdminTestSuite struct {
+	suite.Suite
+
+	level zap.AtomicLevel
+	h     http.Handler
+}
+
+func (s *AdminTestSuite) SetupTest() {
+	s.level = zap.NewAtomicLevelAt(zap.InfoLevel)
+	s.h = rest.NewAPI(zap.NewNop().Sugar(), new(stats.ProviderMock), leagues, rest.WithLogLevel(s.level, "s3cret")).Routes()
+}
+
+func TestAdmin(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(AdminTestSuite))
+}
+
+func (s *AdminTestSuite) do(method, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/admin/log/level", strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	w := httptest.NewRecorder()
+	s.h.ServeHTTP(w, req)
+
+	return w
+}
+
+func (s *AdminTestSuite) TestGetLevel() {
+	w := s.do(http.MethodGet, "s3cret", "")
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"level":"info"}`, w.Body.String())
+	s.Equal("no-store", w.Header().Get("Cache-Control"))
+}
+
+func (s *AdminTestSuite) TestSetLevel() {
+	w := s.do(http.MethodPut, "s3cret", `{"level":"debug"}`)
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"level":"debug"}`, w.Body.String())
+	s.Equal(zap.DebugLevel, s.level.Level())
+
+	w = s.do(http.MethodPut, "s3cret", `{"level":"loud"}`)
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Equal(zap.DebugLevel, s.level.Level())
+}
+
+func (s *AdminTestSuite) TestUnauthorized() {
+	for _, token := range []string{"", "wrong"} {
+		w := s.do(http.MethodPut, token, `{"level":"debug"}`)
+		s.Equal(http.StatusUnauthorized, w.Code)
+		s.Equal(`Bearer realm="admin"`, w.Header().Get("WWW-Authenticate"))
+	}
+
+	s.Equal(zap.InfoLevel, s.level.Level())
+}
+
+func (s *AdminTestSuite) TestMetrics() {
+	for token, exp := range map[string]int{"": http.StatusUnauthorized, "wrong": http.StatusUnauthorized, "s3cret": http.StatusOK} {
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		w := httptest.NewRecorder()
+		s.h.ServeHTTP(w, req)
+		s.Equal(exp, w.Code, token)
+	}
+}
+
+func (s *AdminTestSuite) TestDisabled() {
+	h := rest.NewAPI(zap.NewNop().Sugar(), new(stats.ProviderMock), leagues, rest.WithLogLevel(s.level, "")).Routes()
+
+	for _, path := range []string{"/admin/log/level", "/metrics"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer ")
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		s.Equal(http.StatusNotFound, w.Code, path)
+	}
+}
@@ -0,0 +1,84 @@
+package rest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/ratelimit"
+)
+
+type (
+	budgetKey struct{}
+
+	// budget charges the provider calls of a rate limited request to its
+	// client. A request fanning out to more calls than the client has tokens
+	// for fails instead of fetching on credit.
+	budget struct {
+		rl *ratelimit.Limiter
+		ad ratelimit.Admission
+
+		mu   sync.Mutex
+		paid bool
+	}
+
+	// chargedProvider charges every call to the budget of the request, for the
+	// endpoints that fan out to many of them
+	chargedProvider struct {
+		stats.Provider
+	}
+)
+
+func withBudget(ctx context.Context, b *budget) context.Context {
+	return context.WithValue(ctx, budgetKey{}, b)
+}
+
+// charge spends a token of the client for the call, failing with
+// stats.ErrRateLimited once there are none
+func charge(ctx context.Context) error {
+	b, ok := ctx.Value(budgetKey{}).(*budget)
+	if !ok {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// The admission paid for the first call.
+	if !b.paid {
+		b.paid = true
+
+		return nil
+	}
+
+	if !b.rl.Charge(b.ad) {
+		return stats.ErrRateLimited
+	}
+
+	return nil
+}
+
+func (p chargedProvider) GetScoreboard(ctx context.Context, cmd nba.GetScoreboardCommand) (stats.Scoreboard, error) {
+	if err := charge(ctx); err != nil {
+		return stats.Scoreboard{}, err
+	}
+
+	return p.Provider.GetScoreboard(ctx, cmd)
+}
+
+func (p chargedProvider) GetBoxscore(ctx context.Context, cmd nba.GetBoxscoreCommand) (stats.Boxscore, error) {
+	if err := charge(ctx); err != nil {
+		return stats.Boxscore{}, err
+	}
+
+	return p.Provider.GetBoxscore(ctx, cmd)
+}
+
+func (p chargedProvider) GetSchedule(ctx context.Context, cmd nba.GetScheduleCommand) (stats.Schedule, error) {
+	if err := charge(ctx); err != nil {
+		return stats.Schedule{}, err
+	}
+
+	return p.Provider.GetSchedule(ctx, cmd)
+}
@@ -0,0 +1,166 @@
+package rest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+)
+
+const (
+	noStore   = "no-store"
+	immutable = "public, max-age=31536000, immutable"
+)
+
+type (
+	// CachePolicy sets how long clients and CDNs may cache responses,
+	// depending on the status of the games in them
+	CachePolicy struct {
+		// Scheduled applies to games that haven't started and to days without games
+		Scheduled time.Duration
+		Live      time.Duration
+		// Final applies to finished games. Zero means they never change.
+		Final time.Duration
+	}
+
+	// buffer is a http.ResponseWriter kept in memory, so the response can be
+	// hashed before it is sent
+	buffer struct {
+		bytes.Buffer
+
+		header http.Header
+		code   int
+	}
+)
+
+// DefaultCachePolicy is the CachePolicy used when none is configured
+func DefaultCachePolicy() CachePolicy {
+	return CachePolicy{Scheduled: time.Minute, Live: 5 * time.Second}
+}
+
+func (p CachePolicy) forStatus(s nba.GameStatus) string {
+	switch s {
+	case nba.Final:
+		if p.Final == 0 {
+			return immutable
+		}
+
+		return maxAge(p.Final)
+	case nba.Live:
+		return maxAge(p.Live)
+	default:
+		return maxAge(p.Scheduled)
+	}
+}
+
+// scoreboard caches as its least advanced game. Scoreboards requested without
+// a date are today's and will point at another day tomorrow, so they are never
+// kept for long.
+func (p CachePolicy) scoreboard(sb stats.Scoreboard, dated bool) string {
+	if len(sb.Games) == 0 {
+		return p.forStatus(nba.Scheduled)
+	}
+
+	status := nba.Final
+
+	for _, g := range sb.Games {
+		if g.Status == nba.Live {
+			status = nba.Live
+		} else if g.Status != nba.Final && status != nba.Live {
+			status = nba.Scheduled
+		}
+	}
+
+	if status == nba.Final && !dated {
+		status = nba.Scheduled
+	}
+
+	return p.forStatus(status)
+}
+
+func (p CachePolicy) boxscore(b stats.Boxscore) string {
+	return p.forStatus(b.Status)
+}
+
+// comparison changes with every game either team finishes, so it is cached
+// like a scheduled game, or a live one while the teams are playing each other
+// or games are missing from it
+func (p CachePolicy) comparison(c stats.Comparison) string {
+	if len(c.MissingGames) > 0 {
+		return p.forStatus(nba.Live)
+	}
+
+	for _, m := range c.HeadToHead {
+		if m.Status == nba.Live {
+			return p.forStatus(nba.Live)
+		}
+	}
+
+	return p.forStatus(nba.Scheduled)
+}
+
+func maxAge(d time.Duration) string {
+	return fmt.Sprintf("public, max-age=%d", int(d.Seconds()))
+}
+
+func newBuffer() *buffer {
+	return &buffer{header: make(http.Header), code: http.StatusOK}
+}
+
+func (b *buffer) Header() http.Header { return b.header }
+
+func (b *buffer) WriteHeader(code int) { b.code = code }
+
+// flush sends the buffered response with a strong ETag, or a 304 when it
+// matches the client If-None-Match.
+func (b *buffer) flush(w http.ResponseWriter, r *http.Request, cacheControl string) {
+	for k, v := range b.header {
+		w.Header()[k] = v
+	}
+
+	if b.code != http.StatusOK {
+		w.Header().Set("Cache-Control", noStore)
+		w.WriteHeader(b.code)
+		w.Write(b.Bytes()) //nolint: errcheck
+
+		return
+	}
+
+	sum := sha256.Sum256(b.Bytes())
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", cacheControl)
+
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.Header().Del("Content-Type")
+		w.WriteHeader(http.StatusNotModified)
+
+		return
+	}
+
+	w.WriteHeader(b.code)
+	w.Write(b.Bytes()) //nolint: errcheck
+}
+
+// etagMatches uses the weak comparison RFC 9110 mandates for If-None-Match
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimPrefix(strings.TrimSpace(t), "W/")
+		if t == "*" || t == etag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,211 @@
+package rest_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba/nbatest"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/http/rest"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/storage"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+// update rewrites the contract files with the current responses:
+//
+//	go test ./internal/app/http/rest/ -run TestContract -update
+//
+// v1 responses must never change, only v2 ones while v2 isn't published.
+var update = flag.Bool("update", false, "update contract files")
+
+type ContractTestSuite struct {
+	suite.Suite
+
+	hs interface{ Close() }
+	h  http.Handler
+}
+
+func (s *ContractTestSuite) SetupSuite() {
+	_, hs := nbatest.NewTestServer(nbatest.WithClock(func() time.Time {
+		return time.Date(2022, 10, 19, 12, 0, 0, 0, time.UTC)
+	}))
+
+	var (
+		leagues = nbatest.Leagues(hs.URL)
+		n       = nba.New(http.DefaultClient, leagues)
+	)
+
+	s.hs = hs
+	s.h = rest.NewAPI(zap.NewNop().Sugar(), stats.NewService(
+		stats.NewNBAProvider(n, storage.Noop{}),
+		leagues,
+		stats.WithLeagueProvider(nba.GLeague, stats.NewFileProvider(s.leagueFiles(), leagues)),
+	), leagues).Routes()
+}
+
+// leagueFiles are the v1 responses of the NBA as the files of the G League,
+// with the start times in RFC 3339 and the final status v1 leaves out
+func (s *ContractTestSuite) leagueFiles() fstest.MapFS {
+	sb, err := os.ReadFile(filepath.Join("testdata", "contract", "v1_scoreboard.json"))
+	s.Require().NoError(err)
+
+	bs, err := os.ReadFile(filepath.Join("testdata", "contract", "v1_boxscore.json"))
+	s.Require().NoError(err)
+
+	sb = regexp.MustCompile(`"starts_at":"(\d\d:\d\d) UTC"`).ReplaceAll(sb, []byte(`"status":"final","starts_at":"2022-10-18T$1:00Z"`))
+	bs = bytes.Replace(bs, []byte(`"game_id":"0022200001",`), []byte(`"game_id":"0022200001","status":"final",`), 1)
+
+	return fstest.MapFS{
+		"gleague/scoreboard_2022-10-18.json": {Data: sb},
+		"gleague/boxscore_0022200001.json":   {Data: bs},
+	}
+}
+
+func (s *ContractTestSuite) TearDownSuite() {
+	s.hs.Close()
+}
+
+func TestContract(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(ContractTestSuite))
+}
+
+func (s *ContractTestSuite) get(path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	s.h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+	s.Require().Equal(http.StatusOK, w.Code, path)
+
+	return w
+}
+
+// assertContract compares the body byte for byte, so even float formatting
+// is pinned
+func (s *ContractTestSuite) assertContract(name, body string) {
+	path := filepath.Join("testdata", "contract", name)
+
+	if *update {
+		s.Require().NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+		s.Require().NoError(os.WriteFile(path, []byte(body), 0o644))
+	}
+
+	exp, err := os.ReadFile(path)
+	s.Require().NoError(err)
+
+	s.Equal(string(exp), body, name)
+}
+
+func (s *ContractTestSuite) TestV1() {
+	for name, path := range map[string]string{
+		"v1_scoreboard.json":      "/v1/stats/scoreboard?date=2022-10-18",
+		"v1_boxscore.json":        "/v1/stats/boxscore?gameId=0022200001",
+		"v1_boxscore.csv":         "/v1/stats/boxscore?gameId=0022200001&format=csv",
+		"v1_boxscore.ndjson":      "/v1/stats/boxscore?gameId=0022200002&format=ndjson",
+		"v1_scoreboard.csv":       "/v1/stats/scoreboard?date=2022-10-18&format=csv",
+		"v1_boxscore_second.json": "/v1/stats/boxscore?gameId=0022200002",
+		"v1_compare.json":         "/v1/stats/teams/compare?teamA=BOS&teamB=PHI&season=2022-23",
+		"v1_compare.csv":          "/v1/stats/teams/compare?teamA=BOS&teamB=PHI&format=csv",
+	} {
+		w := s.get(path)
+
+		s.Empty(w.Header().Get("Deprecation"))
+		s.Empty(w.Header().Get("Sunset"))
+		s.assertContract(name, w.Body.String())
+	}
+}
+
+func (s *ContractTestSuite) TestUnversioned() {
+	for _, path := range []string{
+		"/stats/scoreboard?date=2022-10-18",
+		"/stats/boxscore?gameId=0022200001",
+		"/stats/boxscore?gameId=0022200001&format=csv",
+		"/stats/teams/compare?teamA=BOS&teamB=PHI",
+	} {
+		w := s.get(path)
+
+		s.Equal(s.get("/v1"+path).Body.String(), w.Body.String(), path)
+		s.Equal("@1792368000", w.Header().Get("Deprecation"))
+		s.Equal("Mon, 19 Apr 2027 00:00:00 GMT", w.Header().Get("Sunset"))
+
+		route, _, _ := strings.Cut(path, "?")
+		s.Equal(`</v1`+route+`>; rel="successor-version"`, w.Header().Get("Link"))
+	}
+}
+
+// TestFileProvider checks a league served from files answers like the NBA
+func (s *ContractTestSuite) TestFileProvider() {
+	for name, path := range map[string]string{
+		"v1_scoreboard.json": "/v1/stats/scoreboard?date=2022-10-18&league=gleague",
+		"v1_boxscore.json":   "/v1/stats/boxscore?gameId=0022200001&league=gleague",
+		"v1_boxscore.csv":    "/v1/stats/boxscore?gameId=0022200001&league=gleague&format=csv",
+	} {
+		exp, err := os.ReadFile(filepath.Join("testdata", "contract", name))
+		s.Require().NoError(err)
+
+		s.Equal(string(exp), s.get(path).Body.String(), path)
+	}
+}
+
+func (s *ContractTestSuite) TestV2() {
+	for name, path := range map[string]string{
+		"v2_scoreboard.json": "/v2/stats/scoreboard?date=2022-10-18",
+		"v2_boxscore.json":   "/v2/stats/boxscore?gameId=0022200001",
+		"v2_boxscore.csv":    "/v2/stats/boxscore?gameId=0022200001&format=csv",
+		"v2_compare.json":    "/v2/stats/teams/compare?teamA=1610612738&teamB=1610612755",
+		"v2_compare.csv":     "/v2/stats/teams/compare?teamA=BOS&teamB=PHI&format=csv",
+	} {
+		w := s.get(path)
+
+		s.Empty(w.Header().Get("Deprecation"))
+		s.Empty(w.Header().Get("Sunset"))
+		s.assertContract(name, w.Body.String())
+	}
+
+	body := s.get("/v2/stats/boxscore?gameId=0022200001").Body.String()
+	s.NotContains(body, `"3fg`)
+	s.NotContains(s.get("/v2/stats/scoreboard?date=2022-10-18").Body.String(), `"stats"`)
+}
+
+// TestStatus checks the game statuses of the v2 responses decode back, and
+// v1 keeps its schema without them
+func (s *ContractTestSuite) TestStatus() {
+	for _, path := range []string{"/v1/stats/scoreboard?date=2022-10-18", "/v1/stats/boxscore?gameId=0022200001"} {
+		s.NotContains(s.get(path).Body.String(), `"status"`, path)
+	}
+
+	for path, exp := range map[string][]nba.GameStatus{
+		"/v2/stats/scoreboard?date=2022-10-18": {nba.Final, nba.Final},
+		"/v2/stats/boxscore?gameId=0022200001": {nba.Final},
+	} {
+		var body struct {
+			Games  []struct{ Status nba.GameStatus } `json:"games"`
+			Status *nba.GameStatus                   `json:"status"`
+		}
+
+		s.Require().NoError(json.Unmarshal(s.get(path).Body.Bytes(), &body), path)
+
+		var statuses []nba.GameStatus
+		for _, g := range body.Games {
+			statuses = append(statuses, g.Status)
+		}
+
+		if body.Status != nil {
+			statuses = append(statuses, *body.Status)
+		}
+
+		s.Equal(exp, statuses, path)
+	}
+}
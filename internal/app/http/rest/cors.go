@@ -0,0 +1,154 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-chi/cors"
+)
+
+type (
+	// CORSPolicy sets which browser origins may call the API, and how
+	CORSPolicy struct {
+		// AllowedOrigins may have a * wildcard, like https://*.example.com. No
+		// origin is allowed when empty.
+		AllowedOrigins   []string
+		AllowedMethods   []string
+		AllowedHeaders   []string
+		AllowCredentials bool
+		// MaxAge is how long browsers may cache a preflight response
+		MaxAge time.Duration
+		// Routes override the policy of the paths under a prefix, like /stats.
+		// The longest matching prefix wins.
+		Routes map[string]CORSPolicy
+	}
+
+	// corsRoute is the CORS handler of the paths under a prefix
+	corsRoute struct {
+		prefix string
+		c      *cors.Cors
+	}
+
+	// corsHandlers are the CORS handlers of a policy, the routes sorted from the
+	// longest prefix
+	corsHandlers struct {
+		c      *cors.Cors
+		routes []corsRoute
+	}
+)
+
+// exposedHeaders are the response headers browsers may read
+var exposedHeaders = []string{
+	"ETag", "Retry-After", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", requestIDHeader,
+}
+
+// DefaultCORSPolicy is the CORSPolicy used when none is configured
+func DefaultCORSPolicy() CORSPolicy {
+	return CORSPolicy{
+		AllowedOrigins: []string{"https://*pedromealha.dev", "http://localhost*"},
+		AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodOptions},
+		AllowedHeaders: []string{
+			"Accept", "Content-Type", "If-None-Match", apiKeyHeader, requestIDHeader, acceptTimezoneHeader,
+		},
+		AllowCredentials: true,
+		MaxAge:           5 * time.Minute,
+	}
+}
+
+// Validate checks the policy and its routes
+func (p CORSPolicy) Validate() error {
+	var errs []error
+
+	for _, o := range p.AllowedOrigins {
+		if o == "*" && p.AllowCredentials {
+			errs = append(errs, errors.New("cors: credentials can't be allowed to every origin"))
+		} else if strings.Count(o, "*") > 1 {
+			errs = append(errs, fmt.Errorf("cors: origin %q has more than one wildcard", o))
+		}
+	}
+
+	for _, m := range p.AllowedMethods {
+		if m == "" || m != strings.ToUpper(m) {
+			errs = append(errs, fmt.Errorf("cors: invalid method %q", m))
+		}
+	}
+
+	if p.MaxAge < 0 {
+		errs = append(errs, fmt.Errorf("cors: negative max age %s", p.MaxAge))
+	}
+
+	for prefix, rp := range p.Routes {
+		if !strings.HasPrefix(prefix, "/") {
+			errs = append(errs, fmt.Errorf("cors: route %q doesn't start with /", prefix))
+		}
+
+		if len(rp.Routes) > 0 {
+			errs = append(errs, fmt.Errorf("cors: route %q can't have routes", prefix))
+		}
+
+		if err := rp.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("route %s: %w", prefix, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// WithCORSPolicy sets the origins allowed to call the API
+func WithCORSPolicy(p CORSPolicy) Option {
+	return func(a *API) { a.SetCORSPolicy(p) }
+}
+
+// SetCORSPolicy replaces the CORS policy of a running API
+func (a *API) SetCORSPolicy(p CORSPolicy) {
+	h := &corsHandlers{c: newCORS(p)}
+
+	for prefix, rp := range p.Routes {
+		h.routes = append(h.routes, corsRoute{prefix: strings.TrimSuffix(prefix, "/"), c: newCORS(rp)})
+	}
+
+	sort.Slice(h.routes, func(i, j int) bool { return len(h.routes[i].prefix) > len(h.routes[j].prefix) })
+
+	a.cors.Store(h)
+}
+
+// corsHandler applies the current CORS policy of the path, so it can change
+// while serving
+func (a *API) corsHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.cors.Load().forPath(r.URL.Path).Handler(next).ServeHTTP(w, r)
+	})
+}
+
+func (h *corsHandlers) forPath(path string) *cors.Cors {
+	for _, route := range h.routes {
+		if path == route.prefix || strings.HasPrefix(path, route.prefix+"/") {
+			return route.c
+		}
+	}
+
+	return h.c
+}
+
+func newCORS(p CORSPolicy) *cors.Cors {
+	opts := cors.Options{
+		AllowedOrigins:   p.AllowedOrigins,
+		AllowedMethods:   p.AllowedMethods,
+		AllowedHeaders:   p.AllowedHeaders,
+		ExposedHeaders:   exposedHeaders,
+		AllowCredentials: p.AllowCredentials,
+		MaxAge:           int(p.MaxAge.Seconds()),
+	}
+
+	// cors allows every origin when none is set, the opposite of what an empty
+	// list means here.
+	if len(p.AllowedOrigins) == 0 {
+		opts.AllowOriginFunc = func(*http.Request, string) bool { return false }
+	}
+
+	return cors.New(opts)
+}
@@ -0,0 +1,214 @@
+package rest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/http/rest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type CORSTestSuite struct {
+	suite.Suite
+
+	a *rest.API
+	h http.Handler
+}
+
+func (s *CORSTestSuite) SetupTest() {
+	s.a = rest.NewAPI(zap.NewNop().Sugar(), new(stats.ProviderMock), leagues, rest.WithCORSPolicy(rest.CORSPolicy{
+		AllowedOrigins: []string{"https://example.com", "https://*.example.com"},
+		AllowedMethods: []string{http.MethodGet, http.MethodOptions},
+		AllowedHeaders: []string{"Accept", "X-Request-ID"},
+		MaxAge:         10 * time.Minute,
+		Routes: map[string]rest.CORSPolicy{
+			"/stats": {
+				AllowedOrigins:   []string{"https://partner.com"},
+				AllowedMethods:   []string{http.MethodGet},
+				AllowedHeaders:   []string{"X-API-Key"},
+				AllowCredentials: true,
+				MaxAge:           time.Minute,
+			},
+		},
+	}))
+	s.h = s.a.Routes()
+}
+
+func TestCORS(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(CORSTestSuite))
+}
+
+func (s *CORSTestSuite) preflight(path, origin, method, headers string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodOptions, path, nil)
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", method)
+
+	if headers != "" {
+		req.Header.Set("Access-Control-Request-Headers", headers)
+	}
+
+	w := httptest.NewRecorder()
+	s.h.ServeHTTP(w, req)
+
+	return w
+}
+
+func (s *CORSTestSuite) get(path, origin string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set("Origin", origin)
+
+	w := httptest.NewRecorder()
+	s.h.ServeHTTP(w, req)
+
+	return w
+}
+
+func (s *CORSTestSuite) TestPreflight() {
+	tests := []struct {
+		scenario string
+
+		path    string
+		origin  string
+		method  string
+		headers string
+
+		expOrigin      string
+		expMethods     string
+		expHeaders     string
+		expMaxAge      string
+		expCredentials string
+	}{
+		{
+			scenario:   "allowed origin",
+			path:       "/openapi.json",
+			origin:     "https://example.com",
+			method:     http.MethodGet,
+			headers:    "X-Request-ID",
+			expOrigin:  "https://example.com",
+			expMethods: http.MethodGet,
+			expHeaders: "X-Request-Id",
+			expMaxAge:  "600",
+		},
+		{
+			scenario:   "wildcard origin",
+			path:       "/graphql",
+			origin:     "https://app.example.com",
+			method:     http.MethodGet,
+			expOrigin:  "https://app.example.com",
+			expMethods: http.MethodGet,
+			expMaxAge:  "600",
+		},
+		{
+			scenario: "unknown origin",
+			path:     "/openapi.json",
+			origin:   "https://other.com",
+			method:   http.MethodGet,
+		},
+		{
+			scenario: "method not allowed",
+			path:     "/graphql",
+			origin:   "https://example.com",
+			method:   http.MethodPost,
+		},
+		{
+			scenario: "header not allowed",
+			path:     "/graphql",
+			origin:   "https://example.com",
+			method:   http.MethodGet,
+			headers:  "X-API-Key",
+		},
+		{
+			scenario:       "partner route",
+			path:           "/stats/boxscore",
+			origin:         "https://partner.com",
+			method:         http.MethodGet,
+			headers:        "X-API-Key",
+			expOrigin:      "https://partner.com",
+			expMethods:     http.MethodGet,
+			expHeaders:     "X-Api-Key",
+			expMaxAge:      "60",
+			expCredentials: "true",
+		},
+		{
+			scenario: "public origin on a partner route",
+			path:     "/stats/boxscore",
+			origin:   "https://example.com",
+			method:   http.MethodGet,
+		},
+		{
+			scenario: "partner origin on a public route",
+			path:     "/statsx",
+			origin:   "https://partner.com",
+			method:   http.MethodGet,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		s.Run(tt.scenario, func() {
+			w := s.preflight(tt.path, tt.origin, tt.method, tt.headers)
+
+			// Preflights are answered before reaching the routes.
+			s.Equal(http.StatusOK, w.Code)
+			s.Empty(w.Body.String())
+
+			h := w.Header()
+			s.Equal(tt.expOrigin, h.Get("Access-Control-Allow-Origin"))
+			s.Equal(tt.expMethods, h.Get("Access-Control-Allow-Methods"))
+			s.Equal(tt.expHeaders, h.Get("Access-Control-Allow-Headers"))
+			s.Equal(tt.expMaxAge, h.Get("Access-Control-Max-Age"))
+			s.Equal(tt.expCredentials, h.Get("Access-Control-Allow-Credentials"))
+		})
+	}
+}
+
+func (s *CORSTestSuite) TestActualRequest() {
+	w := s.get("/healthz", "https://example.com")
+	s.Equal("https://example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	s.Contains(w.Header().Get("Access-Control-Expose-Headers"), "X-Request-Id")
+
+	s.Empty(s.get("/healthz", "https://other.com").Header().Get("Access-Control-Allow-Origin"))
+}
+
+func (s *CORSTestSuite) TestNoOrigins() {
+	s.a.SetCORSPolicy(rest.CORSPolicy{AllowedMethods: []string{http.MethodGet}})
+
+	s.Empty(s.get("/healthz", "https://example.com").Header().Get("Access-Control-Allow-Origin"))
+}
+
+func (s *CORSTestSuite) TestReload() {
+	s.a.SetCORSPolicy(rest.CORSPolicy{AllowedOrigins: []string{"https://*.other.com"}})
+
+	s.Empty(s.get("/healthz", "https://example.com").Header().Get("Access-Control-Allow-Origin"))
+	s.Equal("https://app.other.com", s.get("/healthz", "https://app.other.com").Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORSPolicyValidate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, rest.DefaultCORSPolicy().Validate())
+
+	err := rest.CORSPolicy{
+		AllowedOrigins:   []string{"*", "https://*.*.com"},
+		AllowedMethods:   []string{"get"},
+		AllowCredentials: true,
+		MaxAge:           -time.Second,
+		Routes: map[string]rest.CORSPolicy{
+			"stats": {},
+		},
+	}.Validate()
+
+	assert.ErrorContains(t, err, "credentials can't be allowed to every origin")
+	assert.ErrorContains(t, err, `origin "https://*.*.com" has more than one wildcard`)
+	assert.ErrorContains(t, err, `invalid method "get"`)
+	assert.ErrorContains(t, err, "negative max age")
+	assert.ErrorContains(t, err, `route "stats" doesn't start with /`)
+}
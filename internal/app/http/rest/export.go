@@ -0,0 +1,324 @@
+package rest
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats"
+)
+
+const (
+	formatJSON   format = "json"
+	formatCSV    format = "csv"
+	formatNDJSON format = "ndjson"
+
+	contentTypeCSV    = "text/csv"
+	contentTypeNDJSON = "application/x-ndjson"
+)
+
+var (
+	// statsColumns are the json names of stats.Stats, in declaration order
+	statsColumns = jsonNames(reflect.TypeOf(stats.Stats{}))
+	// fourFactorsColumns are the json names of stats.FourFactors
+	fourFactorsColumns = jsonNames(reflect.TypeOf(stats.FourFactors{}))
+)
+
+type (
+	format string
+
+	formatKey struct{}
+
+	// table is a flat view of a response, one row per record
+	table struct {
+		header []string
+		rows   [][]any
+	}
+)
+
+// negotiate picks the response format from the format query param, falling
+// back to the Accept header. It fails for unknown format params.
+func negotiate(r *http.Request) (format, error) {
+	switch f := format(r.URL.Query().Get("format")); f {
+	case "":
+	case formatJSON, formatCSV, formatNDJSON:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unsupported format %q", f)
+	}
+
+	var (
+		best     = formatJSON
+		bestQ    = 0.0
+		bestWild = true
+	)
+
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		mt, params, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if v, err := strconv.ParseFloat(params["q"], 64); err == nil {
+			q = v
+		}
+
+		var (
+			f    format
+			wild = strings.HasSuffix(mt, "/*")
+		)
+
+		switch mt {
+		case contentTypeCSV, "text/*":
+			f = formatCSV
+		case contentTypeNDJSON:
+			f = formatNDJSON
+		case "application/json", "application/*", "*/*":
+			f = formatJSON
+		default:
+			continue
+		}
+
+		// An exact type wins over a wildcard of the same quality.
+		if q > bestQ || (q == bestQ && q > 0 && bestWild && !wild) {
+			best, bestQ, bestWild = f, q, wild
+		}
+	}
+
+	return best, nil
+}
+
+// negotiated picks the response format before the handler fetches anything,
+// rejecting unknown formats up front
+func negotiated(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f, err := negotiate(r)
+		if err != nil {
+			w.Header().Set("Cache-Control", noStore)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), formatKey{}, f)))
+	})
+}
+
+// respond writes v in the negotiated format, flat formats are built from tab,
+// with cache validators and the given Cache-Control.
+func (a *API) respond(w http.ResponseWriter, r *http.Request, v any, tab func() table, cacheControl string) {
+	f, _ := r.Context().Value(formatKey{}).(format)
+
+	w.Header().Add("Vary", "Accept")
+
+	buf := newBuffer()
+
+	switch f {
+	case formatCSV:
+		buf.Header().Set("Content-Type", contentTypeCSV+"; charset=utf-8")
+		writeCSV(buf, tab())
+	case formatNDJSON:
+		buf.Header().Set("Content-Type", contentTypeNDJSON)
+		writeNDJSON(buf, tab())
+	default:
+		render.JSON(buf, r, v)
+	}
+
+	buf.flush(w, r, cacheControl)
+}
+
+func writeCSV(w io.Writer, t table) {
+	cw := csv.NewWriter(w)
+	cw.Write(t.header) //nolint: errcheck
+
+	for _, row := range t.rows {
+		record := make([]string, len(row))
+		for i, v := range row {
+			record[i] = formatValue(v)
+		}
+
+		cw.Write(record) //nolint: errcheck
+	}
+
+	cw.Flush()
+}
+
+// writeNDJSON writes one object per row keeping the column order
+func writeNDJSON(w io.Writer, t table) {
+	var b strings.Builder
+
+	for _, row := range t.rows {
+		b.Reset()
+		b.WriteByte('{')
+
+		for i, v := range row {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+
+			k, _ := json.Marshal(t.header[i])
+			val, _ := json.Marshal(v)
+
+			b.Write(k)
+			b.WriteByte(':')
+			b.Write(val)
+		}
+
+		b.WriteString("}\n")
+		w.Write([]byte(b.String())) //nolint: errcheck
+	}
+}
+
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// scoreboardTable flattens a scoreboard into one row per game. v2 adds the
+// start time in loc, when it isn't nil.
+func scoreboardTable(sb stats.Scoreboard, v apiVersion, loc *time.Location) table {
+	local := v == apiV2 && loc != nil
+
+	t := table{
+		header: []string{
+			"date", "game_id", "status", "starts_at",
+			"home_team_id", "home_team_name", "home_team_tricode",
+			"away_team_id", "away_team_name", "away_team_tricode",
+		},
+		rows: make([][]any, len(sb.Games)),
+	}
+
+	if local {
+		t.header = append(t.header, "starts_at_local")
+	}
+
+	date := time.Time(sb.Date).Format("2006-01-02")
+
+	for i, g := range sb.Games {
+		t.rows[i] = []any{
+			date, g.ID, g.Status.String(), time.Time(g.StartsAt).Format(time.RFC3339),
+			g.HomeTeam.ID, g.HomeTeam.Name, g.HomeTeam.Tricode,
+			g.AwayTeam.ID, g.AwayTeam.Name, g.AwayTeam.Tricode,
+		}
+
+		if local {
+			t.rows[i] = append(t.rows[i], time.Time(g.StartsAt).In(loc).Format(time.RFC3339))
+		}
+	}
+
+	return t
+}
+
+// boxscoreTable flattens a box score into one row per player, with the
+// player team in every row and the stats of the version, normalised with per
+func boxscoreTable(b stats.Boxscore, v apiVersion, per stats.Per) table {
+	columns, values := statsColumns, func(p, _, _ stats.Stats) []any { return fieldValues(p) }
+	if v == apiV2 {
+		columns, values = statsV2Columns, func(p, team, opp stats.Stats) []any {
+			return fieldValues(newStatsV2(p, per.PlayerFactor(p, team, opp)))
+		}
+	}
+
+	t := table{
+		header: append([]string{
+			"game_id", "status", "side", "team_id", "team_name", "team_tricode",
+			"first_name", "last_name", "position",
+		}, columns...),
+	}
+
+	for _, side := range []struct {
+		name      string
+		team, opp stats.Team
+	}{
+		{"home", b.HomeTeam, b.AwayTeam},
+		{"away", b.AwayTeam, b.HomeTeam},
+	} {
+		for _, p := range side.team.Players {
+			row := []any{
+				b.GameID, b.Status.String(), side.name, side.team.ID, side.team.Name, side.team.Tricode,
+				p.FirstName, p.LastName, p.Position,
+			}
+
+			t.rows = append(t.rows, append(row, values(p.Stats, side.team.Stats, side.opp.Stats)...))
+		}
+	}
+
+	return t
+}
+
+// comparisonTable flattens a comparison into one row per team, with the
+// season totals in v1 and the averages per game in v2. Head to head games are
+// left out.
+func comparisonTable(c stats.Comparison, v apiVersion) table {
+	columns, values := statsColumns, func(l stats.SeasonLine) []any { return fieldValues(l.Totals) }
+	if v == apiV2 {
+		columns, values = statsV2Columns, func(l stats.SeasonLine) []any { return fieldValues(perGameV2(l.Totals, l.Games)) }
+	}
+
+	header := []string{"season", "side", "team_id", "team_name", "team_tricode", "games", "wins", "losses"}
+	header = append(header, columns...)
+
+	t := table{header: append(header, fourFactorsColumns...)}
+
+	for _, side := range []struct {
+		name string
+		line stats.SeasonLine
+	}{
+		{"a", c.TeamA},
+		{"b", c.TeamB},
+	} {
+		row := []any{c.Season, side.name, side.line.ID, side.line.Name, side.line.Tricode, side.line.Games, side.line.Wins, side.line.Losses}
+		row = append(row, values(side.line)...)
+
+		t.rows = append(t.rows, append(row, fieldValues(side.line.FourFactors)...))
+	}
+
+	return t
+}
+
+// fieldValues are the values of the fields of a struct, in declaration order,
+// leaving out the ones not in its json
+func fieldValues(s any) []any {
+	v := reflect.ValueOf(s)
+	values := make([]any, 0, v.NumField())
+
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).Tag.Get("json") != "-" {
+			values = append(values, v.Field(i).Interface())
+		}
+	}
+
+	return values
+}
+
+// jsonNames are the json names of the fields of a struct, in the order of
+// fieldValues
+func jsonNames(t reflect.Type) []string {
+	names := make([]string, 0, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		if name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ","); name != "-" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
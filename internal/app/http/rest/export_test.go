@@ -0,0 +1,6 @@
+package rest
+
+import "github.com/go-chi/chi/v5"
+
+// Router exposes the routes without the tracing wrapper, for chi.Walk.
+func (a *API) Router() chi.Router { return a.router() }
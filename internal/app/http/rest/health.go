@@ -0,0 +1,145 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/logging"
+)
+
+const (
+	checkTimeout = 2 * time.Second
+	// checkTTL keeps probes from reaching the upstream on every call
+	checkTTL = 10 * time.Second
+
+	statusOK          = "ok"
+	statusUnavailable = "unavailable"
+	statusDegraded    = "degraded"
+	statusDraining    = "draining"
+)
+
+type (
+	// Check fails when a dependency the API needs to serve requests is down
+	Check func(context.Context) error
+
+	// health holds the readiness checks and whether the API is shutting down
+	health struct {
+		draining atomic.Bool
+		checks   map[string]*check
+	}
+
+	check struct {
+		fn Check
+		// degrades reports the failures without failing readiness
+		degrades bool
+
+		mu      sync.Mutex
+		checked time.Time
+		err     error
+	}
+
+	healthResponse struct {
+		Status string            `json:"status"`
+		Checks map[string]string `json:"checks,omitempty"`
+	}
+)
+
+// WithCheck adds a readiness check
+func WithCheck(name string, c Check) Option {
+	return func(a *API) { a.health.checks[name] = &check{fn: c} }
+}
+
+// WithDegradedCheck adds a check of a dependency the API can do without, like
+// the upstream with the storage still answering. Its failures report the API
+// as degraded and keep it ready, so a remote outage doesn't take every
+// replica out of the load balancer.
+func WithDegradedCheck(name string, c Check) Option {
+	return func(a *API) { a.health.checks[name] = &check{fn: c, degrades: true} }
+}
+
+// Drain fails readiness from now on, so the load balancer stops sending
+// requests before the server shuts down
+func (a *API) Drain() {
+	a.health.draining.Store(true)
+}
+
+// getHealth answers as long as the process is up
+func (a *API) getHealth(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", noStore)
+	render.JSON(w, r, healthResponse{Status: statusOK})
+}
+
+// getReadiness fails while draining or when any check fails, except the
+// checks only degrading the API
+func (a *API) getReadiness(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", noStore)
+
+	if a.health.draining.Load() {
+		render.Status(r, http.StatusServiceUnavailable)
+		render.JSON(w, r, healthResponse{Status: statusDraining})
+
+		return
+	}
+
+	var (
+		res = healthResponse{Status: statusOK, Checks: make(map[string]string, len(a.health.checks))}
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+	)
+
+	for name, c := range a.health.checks {
+		wg.Add(1)
+
+		go func(name string, c *check) {
+			defer wg.Done()
+
+			// The error stays in the logs, it may name the upstream hosts.
+			status := statusOK
+			if err := c.run(r.Context()); err != nil {
+				logging.FromContext(r.Context()).Warnw("readiness check failed", "check", name, "err", err)
+
+				status = statusUnavailable
+				if c.degrades {
+					status = statusDegraded
+				}
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			res.Checks[name] = status
+			if status != statusOK && res.Status != statusUnavailable {
+				res.Status = status
+			}
+		}(name, c)
+	}
+
+	wg.Wait()
+
+	if res.Status == statusUnavailable {
+		render.Status(r, http.StatusServiceUnavailable)
+	}
+
+	render.JSON(w, r, res)
+}
+
+// run returns the last result while it is fresh, checking again otherwise
+func (c *check) run(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.checked.IsZero() && time.Since(c.checked) < checkTTL {
+		return c.err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	c.err, c.checked = c.fn(ctx), time.Now()
+
+	return c.err
+}
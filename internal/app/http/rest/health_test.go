@@ -0,0 +1,95 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/http/rest"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type HealthTestSuite struct {
+	suite.Suite
+
+	upstream error
+	storage  error
+	calls    int
+	a        *rest.API
+	h        http.Handler
+}
+
+func (s *HealthTestSuite) SetupTest() {
+	s.upstream, s.storage, s.calls = nil, nil, 0
+
+	s.a = rest.NewAPI(zap.NewNop().Sugar(), new(stats.ProviderMock), leagues,
+		rest.WithDegradedCheck("upstream", func(context.Context) error {
+			s.calls++
+
+			return s.upstream
+		}),
+		rest.WithCheck("storage", func(context.Context) error { return s.storage }),
+	)
+	s.h = s.a.Routes()
+}
+
+func TestHealth(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(HealthTestSuite))
+}
+
+func (s *HealthTestSuite) get(path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	s.h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+	return w
+}
+
+func (s *HealthTestSuite) TestHealth() {
+	s.a.Drain()
+
+	w := s.get("/healthz")
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"status":"ok"}`, w.Body.String())
+	s.Equal("no-store", w.Header().Get("Cache-Control"))
+}
+
+func (s *HealthTestSuite) TestReady() {
+	w := s.get("/readyz")
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"status":"ok","checks":{"upstream":"ok","storage":"ok"}}`, w.Body.String())
+
+	// Results are reused for a while, not to hammer the upstream.
+	s.get("/readyz")
+	s.Equal(1, s.calls)
+}
+
+func (s *HealthTestSuite) TestDegraded() {
+	s.upstream = errFailed
+
+	// The upstream being down doesn't take the API out of the load balancer.
+	w := s.get("/readyz")
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"status":"degraded","checks":{"upstream":"degraded","storage":"ok"}}`, w.Body.String())
+}
+
+func (s *HealthTestSuite) TestNotReady() {
+	s.upstream, s.storage = errFailed, errFailed
+
+	w := s.get("/readyz")
+	s.Equal(http.StatusServiceUnavailable, w.Code)
+	s.JSONEq(`{"status":"unavailable","checks":{"upstream":"degraded","storage":"unavailable"}}`, w.Body.String())
+}
+
+func (s *HealthTestSuite) TestDraining() {
+	s.a.Drain()
+
+	w := s.get("/readyz")
+	s.Equal(http.StatusServiceUnavailable, w.Code)
+	s.JSONEq(`{"status":"draining"}`, w.Body.String())
+	s.Zero(s.calls)
+}
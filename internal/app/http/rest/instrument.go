@@ -0,0 +1,36 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/metrics"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// unmatchedRoute labels requests no route matched, keeping raw paths out of
+// the metrics
+const unmatchedRoute = "unmatched"
+
+// instrument records every request under its chi route pattern, and names the
+// request span after it. It must be the first middleware of the router, for
+// the pattern to be known once the request is served.
+func instrument(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			done = metrics.RequestStarted(r.Method)
+			ww   = middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		)
+
+		next.ServeHTTP(ww, r)
+
+		route := routePattern(r)
+
+		span := trace.SpanFromContext(r.Context())
+		span.SetName(r.Method + " " + route)
+		span.SetAttributes(semconv.HTTPRoute(route))
+
+		done(route, ww.Status())
+	})
+}
@@ -0,0 +1,92 @@
+package rest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/http/rest"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap"
+)
+
+type MetricsTestSuite struct {
+	suite.Suite
+
+	h http.Handler
+}
+
+func (s *MetricsTestSuite) SetupTest() {
+	pm := new(stats.ProviderMock)
+	pm.On("GetBoxscore", mock.Anything, mock.Anything).Return(stats.Boxscore{}, errFailed)
+
+	s.h = rest.NewAPI(zap.NewNop().Sugar(), pm, leagues, rest.WithLogLevel(zap.NewAtomicLevel(), "s3cret")).Routes()
+}
+
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(MetricsTestSuite))
+}
+
+func (s *MetricsTestSuite) get(path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set("Authorization", "Bearer s3cret")
+
+	w := httptest.NewRecorder()
+	s.h.ServeHTTP(w, req)
+
+	return w
+}
+
+func (s *MetricsTestSuite) TestRequests() {
+	s.Equal(http.StatusInternalServerError, s.get("/stats/boxscore?gameId=0022200001").Code)
+	s.Equal(http.StatusNotFound, s.get("/0022200001").Code)
+
+	w := httptest.NewRecorder()
+	s.h.ServeHTTP(w, httptest.NewRequest("PURGE", "/stats/boxscore?gameId=0022200001", nil))
+
+	w = s.get("/metrics")
+	s.Equal(http.StatusOK, w.Code)
+
+	body := w.Body.String()
+	s.Contains(body, `nba_stats_http_requests_total{method="GET",route="/stats/boxscore",status="500"}`)
+	s.Contains(body, `nba_stats_http_requests_total{method="GET",route="unmatched",status="404"}`)
+	s.Contains(body, `nba_stats_http_request_duration_seconds_bucket{method="GET",route="/stats/boxscore",status="500",le="0.005"}`)
+	s.Contains(body, "nba_stats_http_requests_in_flight")
+	s.Contains(body, `nba_stats_http_requests_total{method="other",route="unmatched",status="405"}`)
+	s.NotContains(body, "/0022200001")
+	s.NotContains(body, "PURGE")
+}
+
+func (s *MetricsTestSuite) TestSpans() {
+	sr := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	req := httptest.NewRequest(http.MethodGet, "/stats/boxscore?gameId=0022200001", nil)
+	req.Header.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	s.h.ServeHTTP(httptest.NewRecorder(), req)
+
+	// Other tests run in parallel, only the span of this trace matters.
+	var spans []sdktrace.ReadOnlySpan
+
+	for _, span := range sr.Ended() {
+		if span.SpanContext().TraceID().String() == "4bf92f3577b34da6a3ce929d0e0e4736" {
+			spans = append(spans, span)
+		}
+	}
+
+	s.Require().Len(spans, 1)
+	s.Equal("GET /stats/boxscore", spans[0].Name())
+	s.Equal("00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+	s.Contains(spans[0].Attributes(), attribute.String("http.route", "/stats/boxscore"))
+}
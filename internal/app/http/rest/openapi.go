@@ -0,0 +1,24 @@
+package rest
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+var (
+	//go:embed openapi.json
+	openAPISpec []byte
+
+	//go:embed docs.html
+	docsPage []byte
+)
+
+func (a *API) getOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(openAPISpec) //nolint: errcheck
+}
+
+func (a *API) getDocs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(docsPage) //nolint: errcheck
+}
@@ -0,0 +1,89 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/http/rest"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+// undocumented routes are pages for humans, not part of the API
+var undocumented = map[string]bool{
+	"/":     true,
+	"/docs": true,
+}
+
+type OpenAPITestSuite struct {
+	suite.Suite
+
+	a    *rest.API
+	spec struct {
+		OpenAPI string                            `json:"openapi"`
+		Paths   map[string]map[string]interface{} `json:"paths"`
+	}
+}
+
+func (s *OpenAPITestSuite) SetupTest() {
+	s.a = rest.NewAPI(zap.NewNop().Sugar(), nil, leagues)
+
+	rec := httptest.NewRecorder()
+	s.a.Routes().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	s.Require().Equal(http.StatusOK, rec.Code)
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &s.spec))
+}
+
+func TestOpenAPI(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(OpenAPITestSuite))
+}
+
+func (s *OpenAPITestSuite) TestVersion() {
+	s.Equal("3.1.0", s.spec.OpenAPI)
+}
+
+func (s *OpenAPITestSuite) TestEveryRouteIsDocumented() {
+	registered := make(map[string]bool)
+
+	err := chi.Walk(s.a.Router(), func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		route = strings.TrimSuffix(route, "/*")
+		if undocumented[route] {
+			return nil
+		}
+
+		op := strings.ToLower(method)
+		registered[op+" "+route] = true
+
+		_, ok := s.spec.Paths[route][op]
+		s.True(ok, "%s %s is not in openapi.json", method, route)
+
+		return nil
+	})
+	s.Require().NoError(err)
+
+	for path, ops := range s.spec.Paths {
+		for op := range ops {
+			s.True(registered[op+" "+path], "%s %s is in openapi.json but not registered", op, path)
+		}
+	}
+}
+
+func (s *OpenAPITestSuite) TestDocs() {
+	rec := httptest.NewRecorder()
+	s.a.Routes().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+
+	body, err := io.ReadAll(rec.Body)
+	s.Require().NoError(err)
+
+	s.Equal(http.StatusOK, rec.Code)
+	s.Contains(string(body), `spec-url="openapi.json"`)
+	s.Contains(string(body), "redoc@2.1.5/", "the redoc bundle is pinned to an exact version")
+}
@@ -0,0 +1,72 @@
+package rest
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/logging"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/ratelimit"
+)
+
+const apiKeyHeader = "X-API-Key"
+
+// rateLimit rejects requests with unknown API keys with 401, and requests
+// over the client limit with 429. Every limited response has the
+// X-RateLimit-* headers.
+func (a *API) rateLimit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ad := a.limit.Admit(r.Header.Get(apiKeyHeader), clientIP(r, a.limit))
+		if ad.UnknownKey {
+			logging.FromContext(r.Context()).Warnw("rejected unknown api key")
+
+			w.Header().Set("Cache-Control", noStore)
+			http.Error(w, "invalid API key", http.StatusUnauthorized)
+
+			return
+		}
+
+		setClient(r.Context(), ad.Client)
+
+		if !ad.Limited {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		r = r.WithContext(withBudget(r.Context(), &budget{rl: a.limit, ad: ad}))
+
+		h := w.Header()
+		h.Set("X-RateLimit-Limit", strconv.Itoa(ad.Limit))
+		h.Set("X-RateLimit-Remaining", strconv.Itoa(ad.Remaining))
+		h.Set("X-RateLimit-Reset", strconv.Itoa(seconds(ad.Reset)))
+
+		if !ad.Allowed {
+			h.Set("Retry-After", strconv.Itoa(seconds(ad.RetryAfter)))
+			h.Set("Cache-Control", noStore)
+			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// clientIP is the address the request is limited by, forwarded by a trusted
+// proxy or the connection address
+func clientIP(r *http.Request, rl *ratelimit.Limiter) string {
+	var forwarded string
+	if h := rl.ClientIPHeader(); h != "" {
+		forwarded = strings.Join(r.Header.Values(h), ",")
+	}
+
+	return rl.ClientIP(r.RemoteAddr, forwarded)
+}
+
+// seconds rounds d up to whole seconds
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,215 @@
+package rest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/http/rest"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/ratelimit"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type RateLimitTestSuite struct {
+	suite.Suite
+
+	now time.Time
+	rl  *ratelimit.Limiter
+	h   http.Handler
+}
+
+func (s *RateLimitTestSuite) SetupTest() {
+	s.now = time.Date(2022, 10, 18, 23, 30, 0, 0, time.UTC)
+
+	rl, err := ratelimit.NewLimiter(ratelimit.Limits{
+		Anonymous: ratelimit.Tier{PerMinute: 60, Burst: 2},
+		Tiers: map[string]ratelimit.Tier{
+			"partner":  {PerMinute: 600, Burst: 5},
+			"internal": {},
+		},
+		Keys: []ratelimit.APIKey{
+			{Key: "secret", Client: "celtics", Tier: "partner"},
+			{Key: "internal", Client: "frontend", Tier: "internal"},
+		},
+		ClientIPHeader: "X-Forwarded-For",
+		TrustedProxies: []string{"192.0.2.1"},
+	}, ratelimit.WithClock(func() time.Time { return s.now }))
+	s.Require().NoError(err)
+
+	pm := new(stats.ProviderMock)
+	pm.On("GetScoreboard", mock.Anything, mock.Anything).Return(scoreboard, nil)
+	pm.On("GetBoxscore", mock.Anything, mock.Anything).Return(stats.Boxscore{GameID: "0022200001"}, nil)
+	pm.On("GetSchedule", mock.Anything, mock.Anything).Return(stats.Schedule{Season: "2022-23", Games: []stats.ScheduledGame{
+		{ID: "0022200001", Status: nba.Final, HomeTeam: stats.TeamScore{ID: 1610612738, Tricode: "BOS"}, AwayTeam: stats.TeamScore{ID: 1610612755, Tricode: "PHI"}},
+		{ID: "0022200002", Status: nba.Final, HomeTeam: stats.TeamScore{ID: 1610612738, Tricode: "BOS"}, AwayTeam: stats.TeamScore{ID: 1610612752, Tricode: "NYK"}},
+		{ID: "0022200003", Status: nba.Final, HomeTeam: stats.TeamScore{ID: 1610612755, Tricode: "PHI"}, AwayTeam: stats.TeamScore{ID: 1610612752, Tricode: "NYK"}},
+	}}, nil)
+
+	s.rl = rl
+	s.h = rest.NewAPI(zap.NewNop().Sugar(), pm, leagues, rest.WithRateLimiter(rl)).Routes()
+}
+
+func TestRateLimit(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(RateLimitTestSuite))
+}
+
+func (s *RateLimitTestSuite) get(path string, header map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+
+	w := httptest.NewRecorder()
+	s.h.ServeHTTP(w, req)
+
+	return w
+}
+
+func (s *RateLimitTestSuite) TestAnonymous() {
+	for remaining := 1; remaining >= 0; remaining-- {
+		w := s.get("/stats/scoreboard", nil)
+		s.Equal(http.StatusOK, w.Code)
+		s.Equal("2", w.Header().Get("X-RateLimit-Limit"))
+		s.Equal(strconv.Itoa(remaining), w.Header().Get("X-RateLimit-Remaining"))
+	}
+
+	w := s.get("/stats/scoreboard", nil)
+	s.Equal(http.StatusTooManyRequests, w.Code)
+	s.Equal("1", w.Header().Get("Retry-After"))
+	s.Equal("2", w.Header().Get("X-RateLimit-Reset"))
+	s.Equal("no-store", w.Header().Get("Cache-Control"))
+
+	// Another IP has its own bucket.
+	w = s.get("/stats/scoreboard", map[string]string{"X-Forwarded-For": "192.0.2.2"})
+	s.Equal(http.StatusOK, w.Code)
+
+	s.now = s.now.Add(time.Second)
+
+	w = s.get("/stats/scoreboard", nil)
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *RateLimitTestSuite) TestSpoofedClientIP() {
+	// The proxy appends the address it saw, whatever the client sent before
+	// it is ignored and rotating it doesn't get a new bucket.
+	for i, spoofed := range []string{"198.51.100.1", "198.51.100.2"} {
+		w := s.get("/stats/scoreboard", map[string]string{"X-Forwarded-For": spoofed + ", 203.0.113.7"})
+		s.Equal(strconv.Itoa(1-i), w.Header().Get("X-RateLimit-Remaining"))
+	}
+
+	w := s.get("/stats/scoreboard", map[string]string{"X-Forwarded-For": "198.51.100.3, 203.0.113.7"})
+	s.Equal(http.StatusTooManyRequests, w.Code)
+
+	// The header is ignored when the connection isn't from a trusted proxy.
+	req := httptest.NewRequest(http.MethodGet, "/stats/scoreboard", nil)
+	req.RemoteAddr = "203.0.113.7:1234"
+	req.Header.Set("X-Forwarded-For", "198.51.100.5")
+
+	w = httptest.NewRecorder()
+	s.h.ServeHTTP(w, req)
+	s.Equal(http.StatusTooManyRequests, w.Code)
+}
+
+func (s *RateLimitTestSuite) TestAPIKeys() {
+	for i := 0; i < 5; i++ {
+		w := s.get("/stats/scoreboard", map[string]string{"X-API-Key": "secret"})
+		s.Equal(http.StatusOK, w.Code)
+		s.Equal("5", w.Header().Get("X-RateLimit-Limit"))
+	}
+
+	w := s.get("/stats/scoreboard", map[string]string{"X-API-Key": "secret"})
+	s.Equal(http.StatusTooManyRequests, w.Code)
+
+	// Anonymous requests from the same IP are limited apart.
+	w = s.get("/stats/scoreboard", nil)
+	s.Equal(http.StatusOK, w.Code)
+
+	for i := 0; i < 10; i++ {
+		w = s.get("/stats/scoreboard", map[string]string{"X-API-Key": "internal"})
+		s.Equal(http.StatusOK, w.Code)
+		s.Empty(w.Header().Get("X-RateLimit-Limit"))
+	}
+
+	w = s.get("/stats/scoreboard", map[string]string{"X-API-Key": "unknown"})
+	s.Equal(http.StatusUnauthorized, w.Code)
+}
+
+func (s *RateLimitTestSuite) TestGraphQLFetches() {
+	var (
+		key   = map[string]string{"X-API-Key": "secret"}
+		query = "/graphql?query=" + url.QueryEscape(`{
+			a: boxscore(gameId: "0022200001") { gameId }
+			b: boxscore(gameId: "0022200002") { gameId }
+			c: boxscore(gameId: "0022200003") { gameId }
+		}`)
+	)
+
+	// The admission pays for the first fetch, the other two take a token each.
+	w := s.get(query, key)
+	s.Equal(http.StatusOK, w.Code)
+	s.NotContains(w.Body.String(), "errors")
+
+	w = s.get("/stats/scoreboard", key)
+	s.Equal("1", w.Header().Get("X-RateLimit-Remaining"))
+
+	w = s.get(query, key)
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "rate limit exceeded")
+}
+
+func (s *RateLimitTestSuite) TestCompareFetches() {
+	key := map[string]string{"X-API-Key": "secret"}
+
+	// The admission pays for the schedule, every box score takes a token.
+	w := s.get("/v2/stats/teams/compare?teamA=BOS&teamB=PHI", key)
+	s.Equal(http.StatusOK, w.Code)
+
+	w = s.get("/stats/scoreboard", key)
+	s.Equal("0", w.Header().Get("X-RateLimit-Remaining"))
+
+	// Past a burst, a comparison fails rather than fetch on credit.
+	s.now = s.now.Add(200 * time.Millisecond)
+
+	w = s.get("/v2/stats/teams/compare?teamA=BOS&teamB=PHI", key)
+	s.Equal(http.StatusTooManyRequests, w.Code)
+	s.Equal("no-store", w.Header().Get("Cache-Control"))
+}
+
+func (s *RateLimitTestSuite) TestCompareOverBurst() {
+	// Three box scores cost more than the anonymous burst of two, even with a
+	// full bucket.
+	w := s.get("/v1/stats/teams/compare?teamA=BOS&teamB=PHI", nil)
+	s.Equal(http.StatusTooManyRequests, w.Code)
+	s.Equal("no-store", w.Header().Get("Cache-Control"))
+}
+
+func (s *RateLimitTestSuite) TestUnlimitedRoutes() {
+	for i := 0; i < 5; i++ {
+		w := s.get("/openapi.json", nil)
+		s.Equal(http.StatusOK, w.Code)
+		s.Empty(w.Header().Get("X-RateLimit-Limit"))
+	}
+}
+
+func (s *RateLimitTestSuite) TestUpdate() {
+	s.Require().NoError(s.rl.Update(ratelimit.Limits{Anonymous: ratelimit.Tier{PerMinute: 60, Burst: 1}}))
+
+	s.Equal(http.StatusOK, s.get("/stats/scoreboard", nil).Code)
+	s.Equal(http.StatusTooManyRequests, s.get("/stats/scoreboard", nil).Code)
+
+	// Removed keys are rejected.
+	s.Equal(http.StatusUnauthorized, s.get("/stats/scoreboard", map[string]string{"X-API-Key": "secret"}).Code)
+
+	s.Error(s.rl.Update(ratelimit.Limits{Keys: []ratelimit.APIKey{{Key: "k", Client: "c", Tier: "gold"}}}))
+}
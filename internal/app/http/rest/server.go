@@ -1,93 +1,309 @@
 package rest
 
 import (
+	"errors"
 	"net/http"
+	"sync/atomic"
+	"time"
 
 	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/cors"
-	"github.com/go-chi/render"
 	"github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats"
 	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
-	"go.opencensus.io/plugin/ochttp"
-	"go.opencensus.io/plugin/ochttp/propagation/b3"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/http/graphql"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/logging"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/metrics"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/ratelimit"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.uber.org/zap"
 )
 
-type API struct {
-	logger *zap.SugaredLogger
-	s      stats.Provider
+type (
+	API struct {
+		logger  *zap.SugaredLogger
+		s       stats.Provider
+		leagues *nba.Registry
+		cache   atomic.Pointer[CachePolicy]
+		cors    atomic.Pointer[corsHandlers]
+		limit   *ratelimit.Limiter
+		health  *health
+		admin   *admin
+	}
+
+	// Option configures the API
+	Option func(*API)
+)
+
+// WithCachePolicy sets the Cache-Control of the stats endpoints
+func WithCachePolicy(p CachePolicy) Option {
+	return func(a *API) { a.SetCachePolicy(p) }
 }
 
-// NewAPI creates a new router with the needed endpoints
-func NewAPI(logger *zap.SugaredLogger, s stats.Provider) *API {
-	return &API{logger: logger, s: s}
+// SetCachePolicy replaces the cache policy of a running API
+func (a *API) SetCachePolicy(p CachePolicy) { a.cache.Store(&p) }
+
+// WithRateLimiter authenticates and limits the clients of the stats endpoints
+func WithRateLimiter(rl *ratelimit.Limiter) Option {
+	return func(a *API) { a.limit = rl }
+}
+
+// NewAPI creates a new router with the needed endpoints, serving the leagues
+// of the registry
+func NewAPI(logger *zap.SugaredLogger, s stats.Provider, leagues *nba.Registry, opts ...Option) *API {
+	a := &API{
+		logger:  logger,
+		s:       s,
+		leagues: leagues,
+		health:  &health{checks: make(map[string]*check)},
+	}
+
+	a.SetCachePolicy(DefaultCachePolicy())
+	a.SetCORSPolicy(DefaultCORSPolicy())
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
 }
 
-// Routes exposes rest endpoints
+// Routes exposes rest endpoints, traced with the trace context of the caller
 func (a *API) Routes() http.Handler {
+	return otelhttp.NewHandler(a.router(), "http.server")
+}
+
+func (a *API) router() chi.Router {
 	r := chi.NewRouter()
 
-	r.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   []string{"https://*pedromealha.dev", "http://localhost*"},
-		AllowedMethods:   []string{"GET", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Content-Type"},
-		AllowCredentials: true,
-		MaxAge:           300,
-	}))
+	r.Use(instrument)
+	r.Use(a.accessLog)
+	r.Use(a.corsHandler)
 
 	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("Hello World!"))
 	})
 
-	r.Route("/stats", func(r chi.Router) {
-		r.Get("/scoreboard", a.getScoreboard)
-		r.Get("/boxscore", a.getBoxscore)
+	r.Get("/healthz", a.getHealth)
+	r.Get("/readyz", a.getReadiness)
+
+	// The metrics tell about the upstream and the clients, only for admins.
+	r.With(a.adminOnly).Get("/metrics", metrics.Handler().ServeHTTP)
+
+	r.Get("/openapi.json", a.getOpenAPI)
+	r.Get("/docs", a.getDocs)
+
+	r.Route("/admin", func(r chi.Router) {
+		r.Use(a.adminOnly)
+
+		r.Get("/log/level", a.logLevel)
+		r.Put("/log/level", a.logLevel)
+	})
+
+	// Only the endpoints reaching the upstream are limited.
+	r.Group(func(r chi.Router) {
+		if a.limit != nil {
+			r.Use(a.rateLimit)
+		}
+
+		// A query is charged for every fetch it resolves, not only the first.
+		gql := graphql.NewHandler(chargedProvider{a.s}, a.leagues)
+		r.Method(http.MethodGet, "/graphql", gql)
+		r.Method(http.MethodPost, "/graphql", gql)
+
+		r.Route("/v1/stats", a.statsRoutes(apiV1))
+		r.Route("/v2/stats", a.statsRoutes(apiV2))
+
+		// The unversioned routes predate /v1 and answer the same.
+		r.With(deprecated).Route("/stats", a.statsRoutes(apiV1))
+	})
+
+	return r
+}
+
+// statsRoutes are the stats endpoints answering with the schema of the version
+func (a *API) statsRoutes(v apiVersion) func(chi.Router) {
+	return func(r chi.Router) {
+		r.Use(negotiated)
+
+		r.Get("/scoreboard", a.getScoreboard(v))
+		r.Get("/boxscore", a.getBoxscore(v))
+		r.Get("/teams/compare", a.getTeamsComparison(v))
+	}
+}
+
+// deprecated flags the responses of the unversioned routes with when they
+// stop answering, pointing at their /v1 successor
+func deprecated(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", deprecationHeader)
+		w.Header().Set("Sunset", sunsetHeader)
+		w.Header().Set("Link", "</v1"+r.URL.Path+`>; rel="successor-version"`)
+
+		next.ServeHTTP(w, r)
 	})
+}
 
-	return &ochttp.Handler{
-		Handler:     r,
-		Propagation: &b3.HTTPFormat{},
+func (a *API) getScoreboard(v apiVersion) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		a.scoreboard(w, r, v)
 	}
 }
 
-func (a *API) getScoreboard(w http.ResponseWriter, r *http.Request) {
+func (a *API) getBoxscore(v apiVersion) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		a.boxscore(w, r, v)
+	}
+}
+
+func (a *API) getTeamsComparison(v apiVersion) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		a.compareTeams(w, r, v)
+	}
+}
+
+func (a *API) scoreboard(w http.ResponseWriter, r *http.Request, v apiVersion) {
 	var (
 		ctx = r.Context()
 		cmd nba.GetScoreboardCommand
 	)
 
 	cmd.Date = r.URL.Query().Get("date")
-	cmd.LeagueID = nba.ParseLeague(r.URL.Query().Get("league"))
+	cmd.LeagueID = a.leagues.ByName(r.URL.Query().Get("league")).ID
+
+	w.Header().Add("Vary", acceptTimezoneHeader)
+
+	loc, err := timezone(r)
+	if err != nil {
+		w.Header().Set("Cache-Control", noStore)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	var res stats.Scoreboard
+	if loc != nil {
+		res, err = stats.GetLocalScoreboard(ctx, a.s, cmd, a.leagues.Get(cmd.LeagueID), loc, time.Now())
+	} else {
+		res, err = a.s.GetScoreboard(ctx, cmd)
+	}
+
+	if errors.Is(err, stats.ErrInvalidDate) {
+		w.Header().Set("Cache-Control", noStore)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
 
-	res, err := a.s.GetScoreboard(ctx, cmd)
 	if err != nil {
-		a.logger.Errorw("failed to get scoreboard", "err", err)
+		logging.FromContext(ctx).Errorw("failed to get scoreboard", "err", err)
 
+		w.Header().Set("Cache-Control", noStore)
 		http.Error(w, "failed to get scoreboard", http.StatusInternalServerError)
 
 		return
 	}
 
-	render.JSON(w, r, res)
+	var body any = res
+	if v == apiV2 {
+		body = newScoreboardV2(res, loc)
+	}
+
+	a.respond(w, r, body, func() table { return scoreboardTable(res, v, loc) }, a.cache.Load().scoreboard(res, cmd.Date != ""))
 }
 
-func (a *API) getBoxscore(w http.ResponseWriter, r *http.Request) {
+func (a *API) boxscore(w http.ResponseWriter, r *http.Request, v apiVersion) {
 	var (
 		ctx = r.Context()
 		cmd nba.GetBoxscoreCommand
 	)
 
 	cmd.GameID = r.URL.Query().Get("gameId")
-	cmd.LeagueID = nba.ParseLeague(r.URL.Query().Get("league"))
+	cmd.LeagueID = a.leagues.ByName(r.URL.Query().Get("league")).ID
+
+	// Without a league, game ids tell which one they are from.
+	if l, ok := a.leagues.ByGameID(cmd.GameID); ok && r.URL.Query().Get("league") == "" {
+		cmd.LeagueID = l.ID
+	}
+
+	per, err := stats.ParsePer(r.URL.Query().Get("per"))
+	if err == nil && per != stats.PerGame && v != apiV2 {
+		err = errors.New("per is only supported by /v2")
+	}
+
+	if err != nil {
+		w.Header().Set("Cache-Control", noStore)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
 
 	res, err := a.s.GetBoxscore(ctx, cmd)
 	if err != nil {
-		a.logger.Errorw("failed to get boxscore", "err", err)
+		logging.FromContext(ctx).Errorw("failed to get boxscore", "err", err)
 
+		w.Header().Set("Cache-Control", noStore)
 		http.Error(w, "failed to get boxscore", http.StatusInternalServerError)
 
 		return
 	}
 
-	render.JSON(w, r, res)
+	var body any = res
+	if v == apiV2 {
+		body = newBoxscoreV2(res, per)
+	}
+
+	a.respond(w, r, body, func() table { return boxscoreTable(res, v, per) }, a.cache.Load().boxscore(res))
+}
+
+func (a *API) compareTeams(w http.ResponseWriter, r *http.Request, v apiVersion) {
+	var (
+		ctx = r.Context()
+		cmd = stats.CompareTeamsCommand{
+			LeagueID: a.leagues.ByName(r.URL.Query().Get("league")).ID,
+			TeamA:    r.URL.Query().Get("teamA"),
+			TeamB:    r.URL.Query().Get("teamB"),
+			Season:   r.URL.Query().Get("season"),
+		}
+	)
+
+	if cmd.TeamA == "" || cmd.TeamB == "" {
+		w.Header().Set("Cache-Control", noStore)
+		http.Error(w, "teamA and teamB are required", http.StatusBadRequest)
+
+		return
+	}
+
+	// A comparison is charged for every box score it fetches.
+	res, err := stats.CompareTeams(ctx, chargedProvider{a.s}, cmd)
+
+	switch {
+	case errors.Is(err, stats.ErrInvalidTeams):
+		w.Header().Set("Cache-Control", noStore)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	case errors.Is(err, stats.ErrSeasonUnavailable):
+		w.Header().Set("Cache-Control", noStore)
+		http.Error(w, err.Error(), http.StatusNotFound)
+
+		return
+	case errors.Is(err, stats.ErrRateLimited):
+		w.Header().Set("Cache-Control", noStore)
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+
+		return
+	case err != nil:
+		logging.FromContext(ctx).Errorw("failed to compare teams", "err", err)
+
+		w.Header().Set("Cache-Control", noStore)
+		http.Error(w, "failed to compare teams", http.StatusInternalServerError)
+
+		return
+	}
+
+	var body any = res
+	if v == apiV2 {
+		body = newComparisonV2(res)
+	}
+
+	a.respond(w, r, body, func() table { return comparisonTable(res, v) }, a.cache.Load().comparison(res))
 }
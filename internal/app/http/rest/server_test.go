@@ -0,0 +1,539 @@
+package rest_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba/nbatest"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/http/rest"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+var (
+	errFailed = errors.New("failed")
+
+	leagues = nbatest.Leagues("https://nba.test")
+
+	scoreboard = stats.Scoreboard{
+		Date: nba.GameDate(time.Date(2022, 10, 18, 0, 0, 0, 0, time.UTC)),
+		Games: []stats.Game{
+			{
+				ID:       "0022200001",
+				Status:   nba.Final,
+				StartsAt: nba.GameTime(time.Date(2022, 10, 18, 23, 30, 0, 0, time.UTC)),
+				HomeTeam: stats.Team{ID: 1610612738, Name: "Celtics", Tricode: "BOS"},
+				AwayTeam: stats.Team{ID: 1610612755, Name: "76ers", Tricode: "PHI"},
+			},
+		},
+	}
+
+	boxscore = stats.Boxscore{
+		GameID: "0022200001",
+		Status: nba.Final,
+		HomeTeam: stats.Team{
+			ID: 1610612738, Name: "Celtics", Tricode: "BOS",
+			Players: []stats.Player{
+				{FirstName: "Jayson", LastName: "Tatum", Position: "SF", Stats: stats.Stats{Minutes: "35:40", PT: 35, FGP: 59.1}},
+			},
+		},
+		AwayTeam: stats.Team{
+			ID: 1610612755, Name: "76ers", Tricode: "PHI",
+			Players: []stats.Player{
+				{FirstName: "Joel", LastName: "Embiid", Position: "C", Stats: stats.Stats{Minutes: "35:22", PT: 26}},
+			},
+		},
+	}
+)
+
+type ServerTestSuite struct {
+	suite.Suite
+
+	pm *stats.ProviderMock
+	h  http.Handler
+}
+
+func (s *ServerTestSuite) SetupTest() {
+	s.pm = new(stats.ProviderMock)
+	s.h = rest.NewAPI(zap.NewNop().Sugar(), s.pm, leagues).Routes()
+}
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(ServerTestSuite))
+}
+
+func (s *ServerTestSuite) TestGetScoreboard() {
+	tests := []struct {
+		scenario string
+
+		url    string
+		accept string
+		err    error
+
+		expCode        int
+		expContentType string
+		expBody        string
+		expNoFetch     bool
+	}{
+		{
+			scenario:       "json by default",
+			url:            "/stats/scoreboard?date=2022-10-18",
+			expCode:        http.StatusOK,
+			expContentType: "application/json",
+			expBody: `{"date":"2022-10-18","games":[{"id":"0022200001","starts_at":"23:30 UTC",` +
+				`"home_team":{"id":1610612738,"name":"Celtics","tricode":"BOS","stats":{"min":"","fgm":0,"fga":0,"fgp":0,"3fgm":0,"3fga":0,"3fgp":0,"ftm":0,"fta":0,"ftp":0,"oreb":0,"dreb":0,"reb":0,"rebt":0,"ast":0,"stl":0,"blk":0,"to":0,"tot":0,"pf":0,"fd":0,"pts":0,"plus_minus":0}},` +
+				`"away_team":{"id":1610612755,"name":"76ers","tricode":"PHI","stats":{"min":"","fgm":0,"fga":0,"fgp":0,"3fgm":0,"3fga":0,"3fgp":0,"ftm":0,"fta":0,"ftp":0,"oreb":0,"dreb":0,"reb":0,"rebt":0,"ast":0,"stl":0,"blk":0,"to":0,"tot":0,"pf":0,"fd":0,"pts":0,"plus_minus":0}}}]}` + "\n",
+		},
+		{
+			scenario:       "csv from accept header",
+			url:            "/stats/scoreboard?date=2022-10-18",
+			accept:         "text/csv",
+			expCode:        http.StatusOK,
+			expContentType: "text/csv; charset=utf-8",
+			expBody: "date,game_id,status,starts_at,home_team_id,home_team_name,home_team_tricode,away_team_id,away_team_name,away_team_tricode\n" +
+				"2022-10-18,0022200001,final,2022-10-18T23:30:00Z,1610612738,Celtics,BOS,1610612755,76ers,PHI\n",
+		},
+		{
+			scenario:       "ndjson from format param over accept header",
+			url:            "/stats/scoreboard?date=2022-10-18&format=ndjson",
+			accept:         "text/csv",
+			expCode:        http.StatusOK,
+			expContentType: "application/x-ndjson",
+			expBody: `{"date":"2022-10-18","game_id":"0022200001","status":"final","starts_at":"2022-10-18T23:30:00Z",` +
+				`"home_team_id":1610612738,"home_team_name":"Celtics","home_team_tricode":"BOS",` +
+				`"away_team_id":1610612755,"away_team_name":"76ers","away_team_tricode":"PHI"}` + "\n",
+		},
+		{
+			scenario:       "preferred accept quality",
+			url:            "/stats/scoreboard?date=2022-10-18",
+			accept:         "text/csv;q=0.5, application/x-ndjson",
+			expCode:        http.StatusOK,
+			expContentType: "application/x-ndjson",
+		},
+		{
+			scenario:       "csv from accept wildcard",
+			url:            "/stats/scoreboard?date=2022-10-18",
+			accept:         "text/*",
+			expCode:        http.StatusOK,
+			expContentType: "text/csv; charset=utf-8",
+		},
+		{
+			scenario:       "exact accept type over wildcard",
+			url:            "/stats/scoreboard?date=2022-10-18",
+			accept:         "*/*, application/x-ndjson",
+			expCode:        http.StatusOK,
+			expContentType: "application/x-ndjson",
+		},
+		{
+			scenario:   "unknown format",
+			url:        "/stats/scoreboard?date=2022-10-18&format=xml",
+			expCode:    http.StatusBadRequest,
+			expNoFetch: true,
+		},
+		{
+			scenario: "failed to get scoreboard",
+			url:      "/stats/scoreboard?date=2022-10-18",
+			err:      errFailed,
+			expCode:  http.StatusInternalServerError,
+			expBody:  "failed to get scoreboard\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		s.Run(tt.scenario, func() {
+			s.SetupTest()
+
+			s.pm.On("GetScoreboard", mock.Anything, nba.GetScoreboardCommand{Date: "2022-10-18", LeagueID: nba.NBA}).Return(scoreboard, tt.err)
+
+			rec := s.get(tt.url, tt.accept)
+
+			s.Equal(tt.expCode, rec.Code)
+
+			if tt.expContentType != "" {
+				s.Equal(tt.expContentType, rec.Header().Get("Content-Type"))
+			}
+
+			if tt.expBody != "" {
+				s.Equal(tt.expBody, rec.Body.String())
+			}
+
+			if tt.expNoFetch {
+				s.pm.AssertNotCalled(s.T(), "GetScoreboard", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func (s *ServerTestSuite) TestGetBoxscoreCSV() {
+	s.pm.On("GetBoxscore", mock.Anything, nba.GetBoxscoreCommand{GameID: "0022200001", LeagueID: nba.NBA}).Return(boxscore, nil)
+
+	rec := s.get("/stats/boxscore?gameId=0022200001&format=csv", "")
+
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(
+		"game_id,status,side,team_id,team_name,team_tricode,first_name,last_name,position,"+
+			"min,fgm,fga,fgp,3fgm,3fga,3fgp,ftm,fta,ftp,oreb,dreb,reb,rebt,ast,stl,blk,to,tot,pf,fd,pts,plus_minus\n"+
+			"0022200001,final,home,1610612738,Celtics,BOS,Jayson,Tatum,SF,35:40,0,0,59.1,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,35,0\n"+
+			"0022200001,final,away,1610612755,76ers,PHI,Joel,Embiid,C,35:22,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,26,0\n",
+		rec.Body.String(),
+	)
+}
+
+func (s *ServerTestSuite) get(url, accept string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	rec := httptest.NewRecorder()
+	s.h.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func (s *ServerTestSuite) TestCaching() {
+	live := boxscore
+	live.Status = nba.Live
+
+	tests := []struct {
+		scenario string
+
+		url string
+		res stats.Boxscore
+
+		expCacheControl string
+	}{
+		{
+			scenario:        "final games are immutable",
+			url:             "/stats/boxscore?gameId=0022200001",
+			res:             boxscore,
+			expCacheControl: "public, max-age=31536000, immutable",
+		},
+		{
+			scenario:        "live games are cached for seconds",
+			url:             "/stats/boxscore?gameId=0022200001",
+			res:             live,
+			expCacheControl: "public, max-age=5",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		s.Run(tt.scenario, func() {
+			s.SetupTest()
+
+			s.pm.On("GetBoxscore", mock.Anything, mock.Anything).Return(tt.res, nil)
+
+			rec := s.get(tt.url, "")
+
+			s.Equal(http.StatusOK, rec.Code)
+			s.Equal(tt.expCacheControl, rec.Header().Get("Cache-Control"))
+
+			etag := rec.Header().Get("ETag")
+			s.NotEmpty(etag)
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			req.Header.Set("If-None-Match", `"other", `+etag)
+
+			rec = httptest.NewRecorder()
+			s.h.ServeHTTP(rec, req)
+
+			s.Equal(http.StatusNotModified, rec.Code)
+			s.Empty(rec.Body.String())
+			s.Equal(etag, rec.Header().Get("ETag"))
+
+			// Each format has its own validator.
+			s.NotEqual(etag, s.get(tt.url+"&format=csv", "").Header().Get("ETag"))
+		})
+	}
+}
+
+func (s *ServerTestSuite) TestScoreboardCaching() {
+	tests := []struct {
+		scenario string
+
+		url   string
+		games []stats.Game
+
+		expCacheControl string
+	}{
+		{
+			scenario:        "finished day",
+			url:             "/stats/scoreboard?date=2022-10-18",
+			games:           []stats.Game{{Status: nba.Final}, {Status: nba.Final}},
+			expCacheControl: "public, max-age=31536000, immutable",
+		},
+		{
+			scenario:        "finished day requested as today",
+			url:             "/stats/scoreboard",
+			games:           []stats.Game{{Status: nba.Final}},
+			expCacheControl: "public, max-age=60",
+		},
+		{
+			scenario:        "day with a live game",
+			url:             "/stats/scoreboard?date=2022-10-18",
+			games:           []stats.Game{{Status: nba.Final}, {Status: nba.Live}, {Status: nba.Scheduled}},
+			expCacheControl: "public, max-age=5",
+		},
+		{
+			scenario:        "day without games",
+			url:             "/stats/scoreboard?date=2022-10-18",
+			expCacheControl: "public, max-age=60",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		s.Run(tt.scenario, func() {
+			s.SetupTest()
+
+			s.pm.On("GetScoreboard", mock.Anything, mock.Anything).Return(stats.Scoreboard{Games: tt.games}, nil)
+
+			rec := s.get(tt.url, "")
+
+			s.Equal(http.StatusOK, rec.Code)
+			s.Equal(tt.expCacheControl, rec.Header().Get("Cache-Control"))
+		})
+	}
+}
+
+func (s *ServerTestSuite) TestCachePolicyReload() {
+	a := rest.NewAPI(zap.NewNop().Sugar(), s.pm, leagues)
+	h := a.Routes()
+
+	live := boxscore
+	live.Status = nba.Live
+
+	s.pm.On("GetBoxscore", mock.Anything, mock.Anything).Return(live, nil)
+
+	a.SetCachePolicy(rest.CachePolicy{Live: 30 * time.Second})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stats/boxscore?gameId=0022200001", nil))
+
+	s.Equal("public, max-age=30", rec.Header().Get("Cache-Control"))
+}
+
+func (s *ServerTestSuite) TestScoreboardTimezone() {
+	tests := []struct {
+		scenario string
+
+		url      string
+		timezone string
+
+		expCode int
+		expBody string
+	}{
+		{
+			scenario: "tz param",
+			url:      "/v2/stats/scoreboard?date=2022-10-19&tz=Europe/Lisbon",
+			expCode:  http.StatusOK,
+			expBody: `{"date":"2022-10-19","timezone":"Europe/Lisbon","games":[{"id":"0022200001","status":"final",` +
+				`"starts_at":"2022-10-18T23:30:00Z","starts_at_local":"2022-10-19T00:30:00+01:00",` +
+				`"home_team":{"id":1610612738,"name":"Celtics","tricode":"BOS"},"away_team":{"id":1610612755,"name":"76ers","tricode":"PHI"}}]}` + "\n",
+		},
+		{
+			scenario: "accept-timezone header",
+			url:      "/v2/stats/scoreboard?date=2022-10-19&format=csv",
+			timezone: "Europe/Lisbon",
+			expCode:  http.StatusOK,
+			expBody: "date,game_id,status,starts_at,home_team_id,home_team_name,home_team_tricode,away_team_id,away_team_name,away_team_tricode,starts_at_local\n" +
+				"2022-10-19,0022200001,final,2022-10-18T23:30:00Z,1610612738,Celtics,BOS,1610612755,76ers,PHI,2022-10-19T00:30:00+01:00\n",
+		},
+		{
+			scenario: "tz param over header",
+			url:      "/v1/stats/scoreboard?date=2022-10-19&tz=Europe/Lisbon&format=csv",
+			timezone: "Asia/Tokyo",
+			expCode:  http.StatusOK,
+			expBody: "date,game_id,status,starts_at,home_team_id,home_team_name,home_team_tricode,away_team_id,away_team_name,away_team_tricode\n" +
+				"2022-10-19,0022200001,final,2022-10-18T23:30:00Z,1610612738,Celtics,BOS,1610612755,76ers,PHI\n",
+		},
+		{
+			scenario: "unknown timezone",
+			url:      "/v2/stats/scoreboard?date=2022-10-19&tz=Mars/Olympus",
+			expCode:  http.StatusBadRequest,
+			expBody:  "unknown timezone \"Mars/Olympus\"\n",
+		},
+		{
+			scenario: "invalid date",
+			url:      "/v2/stats/scoreboard?date=19-10-2022&tz=Europe/Lisbon",
+			expCode:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		s.Run(tt.scenario, func() {
+			s.SetupTest()
+
+			s.pm.On("GetScoreboard", mock.Anything, nba.GetScoreboardCommand{Date: "2022-10-18", LeagueID: nba.NBA}).Return(scoreboard, nil).Maybe()
+			s.pm.On("GetScoreboard", mock.Anything, nba.GetScoreboardCommand{Date: "2022-10-19", LeagueID: nba.NBA}).Return(stats.Scoreboard{}, nil).Maybe()
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.timezone != "" {
+				req.Header.Set("Accept-Timezone", tt.timezone)
+			}
+
+			rec := httptest.NewRecorder()
+			s.h.ServeHTTP(rec, req)
+
+			s.Equal(tt.expCode, rec.Code)
+			s.Contains(rec.Header().Values("Vary"), "Accept-Timezone")
+
+			if tt.expBody != "" {
+				s.Equal(tt.expBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func (s *ServerTestSuite) TestBoxscorePer() {
+	b := boxscore
+	b.HomeTeam.Stats = stats.Stats{Minutes: "240:00", SecondsPlayed: 14400, PT: 110}
+	b.HomeTeam.Players = []stats.Player{
+		{FirstName: "Jayson", LastName: "Tatum", Stats: stats.Stats{Minutes: "24:00", SecondsPlayed: 1440, PT: 20, FGP: 50, PlusMinus: 7}},
+	}
+
+	tests := []struct {
+		scenario string
+
+		url string
+
+		expCode int
+		expBody string
+	}{
+		{
+			scenario: "per 36 minutes",
+			url:      "/v2/stats/boxscore?gameId=0022200001&per=36&format=csv",
+			expCode:  http.StatusOK,
+			expBody: "game_id,status,side,team_id,team_name,team_tricode,first_name,last_name,position," +
+				"min,seconds_played,fgm,fga,fgp,fg3m,fg3a,fg3p,ftm,fta,ftp,oreb,dreb,reb,rebt,ast,stl,blk,to,tot,pf,fd,pts,plus_minus\n" +
+				"0022200001,final,home,1610612738,Celtics,BOS,Jayson,Tatum,,24:00,1440,0,0,50,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,30,10.5\n" +
+				"0022200001,final,away,1610612755,76ers,PHI,Joel,Embiid,C,35:22,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0\n",
+		},
+		{
+			scenario: "per on v1",
+			url:      "/v1/stats/boxscore?gameId=0022200001&per=36",
+			expCode:  http.StatusBadRequest,
+			expBody:  "per is only supported by /v2\n",
+		},
+		{
+			scenario: "unknown per",
+			url:      "/v2/stats/boxscore?gameId=0022200001&per=48",
+			expCode:  http.StatusBadRequest,
+			expBody:  "invalid per \"48\", must be 36 or 100poss\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		s.Run(tt.scenario, func() {
+			s.SetupTest()
+
+			s.pm.On("GetBoxscore", mock.Anything, mock.Anything).Return(b, nil).Maybe()
+
+			rec := s.get(tt.url, "")
+
+			s.Equal(tt.expCode, rec.Code)
+			s.Equal(tt.expBody, rec.Body.String())
+		})
+	}
+}
+
+func (s *ServerTestSuite) TestBoxscoreLeagueOfGame() {
+	s.pm.On("GetBoxscore", mock.Anything, nba.GetBoxscoreCommand{GameID: "1022200001", LeagueID: nba.WNBA}).Return(boxscore, nil).Once()
+	s.pm.On("GetBoxscore", mock.Anything, nba.GetBoxscoreCommand{GameID: "2022300001", LeagueID: nba.GLeague}).Return(boxscore, nil).Once()
+	// The league param wins over the game id.
+	s.pm.On("GetBoxscore", mock.Anything, nba.GetBoxscoreCommand{GameID: "1022200001", LeagueID: nba.NBA}).Return(boxscore, nil).Once()
+
+	for _, url := range []string{
+		"/v2/stats/boxscore?gameId=1022200001",
+		"/v2/stats/boxscore?gameId=2022300001",
+		"/v2/stats/boxscore?gameId=1022200001&league=nba",
+	} {
+		s.Equal(http.StatusOK, s.get(url, "").Code, url)
+	}
+
+	s.pm.AssertExpectations(s.T())
+}
+
+func (s *ServerTestSuite) TestCompareTeams() {
+	sc := stats.Schedule{Season: "2022-23", Games: []stats.ScheduledGame{{
+		ID:       "0022200001",
+		Status:   nba.Scheduled,
+		HomeTeam: stats.TeamScore{ID: 1610612738, Name: "Celtics", Tricode: "BOS"},
+		AwayTeam: stats.TeamScore{ID: 1610612755, Name: "76ers", Tricode: "PHI"},
+	}}}
+
+	tests := []struct {
+		scenario string
+
+		url         string
+		scheduleErr error
+
+		expCode int
+		expBody string
+	}{
+		{
+			scenario: "compare",
+			url:      "/v1/stats/teams/compare?teamA=BOS&teamB=PHI&format=csv",
+			expCode:  http.StatusOK,
+			expBody:  "2022-23,a,1610612738,Celtics,BOS,0,0,0,0:00,",
+		},
+		{
+			scenario: "missing team",
+			url:      "/v2/stats/teams/compare?teamA=BOS",
+			expCode:  http.StatusBadRequest,
+			expBody:  "teamA and teamB are required\n",
+		},
+		{
+			scenario: "unknown team",
+			url:      "/v2/stats/teams/compare?teamA=BOS&teamB=NYK",
+			expCode:  http.StatusBadRequest,
+			expBody:  "invalid teams: unknown team \"NYK\"\n",
+		},
+		{
+			scenario: "past season",
+			url:      "/v2/stats/teams/compare?teamA=BOS&teamB=PHI&season=2021-22",
+			expCode:  http.StatusNotFound,
+			expBody:  "season unavailable: \"2021-22\", the schedule is of 2022-23\n",
+		},
+		{
+			scenario: "failed to get schedule",
+			url:      "/v2/stats/teams/compare?teamA=BOS&teamB=PHI",
+			expCode:  http.StatusInternalServerError,
+			expBody:  "failed to compare teams\n",
+
+			scheduleErr: errors.New("failed"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		s.Run(tt.scenario, func() {
+			s.SetupTest()
+
+			s.pm.On("GetSchedule", mock.Anything, nba.GetScheduleCommand{LeagueID: nba.NBA}).Return(sc, tt.scheduleErr).Maybe()
+
+			rec := s.get(tt.url, "")
+
+			s.Equal(tt.expCode, rec.Code)
+			s.Contains(rec.Body.String(), tt.expBody)
+		})
+	}
+}
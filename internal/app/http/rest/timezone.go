@@ -0,0 +1,30 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const acceptTimezoneHeader = "Accept-Timezone"
+
+// timezone is the location named by the tz query param, falling back to the
+// Accept-Timezone header. It is nil when the client sets neither.
+func timezone(r *http.Request) (*time.Location, error) {
+	name := r.URL.Query().Get("tz")
+	if name == "" {
+		name = r.Header.Get(acceptTimezoneHeader)
+	}
+
+	if name == "" {
+		return nil, nil
+	}
+
+	// Local is the timezone of the server, meaningless to clients.
+	loc, err := time.LoadLocation(name)
+	if err != nil || name == "Local" {
+		return nil, fmt.Errorf("unknown timezone %q", name)
+	}
+
+	return loc, nil
+}
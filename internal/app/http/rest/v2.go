@@ -0,0 +1,307 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/domain/stats"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+)
+
+const (
+	apiV1 apiVersion = 1
+	apiV2 apiVersion = 2
+)
+
+var (
+	// statsV2Columns are the json names of statsV2, in declaration order
+	statsV2Columns = jsonNames(reflect.TypeOf(statsV2{}))
+
+	// unversionedDeprecated is when the unversioned routes were deprecated,
+	// and unversionedSunset when they stop answering
+	unversionedDeprecated = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	unversionedSunset     = time.Date(2027, time.April, 19, 0, 0, 0, 0, time.UTC)
+
+	// The dates as the RFC 9745 Deprecation and RFC 8594 Sunset headers
+	deprecationHeader = fmt.Sprintf("@%d", unversionedDeprecated.Unix())
+	sunsetHeader      = unversionedSunset.Format(http.TimeFormat)
+)
+
+type (
+	// apiVersion is the response schema of a route. v1 is the schema of the
+	// unversioned routes, kept as is for existing consumers.
+	apiVersion int
+
+	// scoreboardV2 has RFC 3339 start times and leaves out the empty stats of
+	// the teams. With a timezone, start times are also given in it.
+	scoreboardV2 struct {
+		Date     nba.GameDate `json:"date"`
+		Timezone string       `json:"timezone,omitempty"`
+		Games    []gameV2     `json:"games"`
+	}
+
+	gameV2 struct {
+		ID            string         `json:"id"`
+		Status        nba.GameStatus `json:"status"`
+		StartsAt      string         `json:"starts_at"`
+		StartsAtLocal string         `json:"starts_at_local,omitempty"`
+		HomeTeam      teamRefV2      `json:"home_team"`
+		AwayTeam      teamRefV2      `json:"away_team"`
+	}
+
+	teamRefV2 struct {
+		ID      int64  `json:"id"`
+		Name    string `json:"name"`
+		Tricode string `json:"tricode"`
+	}
+
+	boxscoreV2 struct {
+		GameID string         `json:"game_id"`
+		Status nba.GameStatus `json:"status"`
+		// Per is how the stat lines are normalised, left out when they aren't
+		Per      stats.Per `json:"per,omitempty"`
+		HomeTeam teamV2    `json:"home_team"`
+		AwayTeam teamV2    `json:"away_team"`
+	}
+
+	teamV2 struct {
+		ID      int64      `json:"id"`
+		Name    string     `json:"name"`
+		Tricode string     `json:"tricode"`
+		Stats   statsV2    `json:"stats"`
+		Players []playerV2 `json:"players"`
+	}
+
+	playerV2 struct {
+		FirstName string  `json:"first_name"`
+		LastName  string  `json:"last_name"`
+		Position  string  `json:"position"`
+		Stats     statsV2 `json:"stats"`
+	}
+
+	// comparisonV2 has the season lines per game too, and RFC 3339 start times
+	comparisonV2 struct {
+		Season        string          `json:"season"`
+		TeamA         seasonLineV2    `json:"team_a"`
+		TeamB         seasonLineV2    `json:"team_b"`
+		HeadToHead    []matchupV2     `json:"head_to_head"`
+		Differentials differentialsV2 `json:"differentials"`
+		MissingGames  []string        `json:"missing_games"`
+	}
+
+	seasonLineV2 struct {
+		ID                  int64             `json:"id"`
+		Name                string            `json:"name"`
+		Tricode             string            `json:"tricode"`
+		Games               int               `json:"games"`
+		Wins                int               `json:"wins"`
+		Losses              int               `json:"losses"`
+		Totals              statsV2           `json:"totals"`
+		PerGame             statsV2           `json:"per_game"`
+		OpponentPerGame     statsV2           `json:"opponent_per_game"`
+		FourFactors         stats.FourFactors `json:"four_factors"`
+		OpponentFourFactors stats.FourFactors `json:"opponent_four_factors"`
+	}
+
+	matchupV2 struct {
+		GameID        string           `json:"game_id"`
+		Status        nba.GameStatus   `json:"status"`
+		StartsAt      string           `json:"starts_at"`
+		HomeTeamID    int64            `json:"home_team_id"`
+		TeamAScore    int64            `json:"team_a_score"`
+		TeamBScore    int64            `json:"team_b_score"`
+		Differentials *differentialsV2 `json:"differentials,omitempty"`
+	}
+
+	differentialsV2 struct {
+		PT          float64           `json:"pts"`
+		RT          float64           `json:"reb"`
+		AST         float64           `json:"ast"`
+		STL         float64           `json:"stl"`
+		BLK         float64           `json:"blk"`
+		TO          float64           `json:"to"`
+		FGP         float64           `json:"fgp"`
+		ThreeFGP    float64           `json:"fg3p"`
+		FTP         float64           `json:"ftp"`
+		FourFactors stats.FourFactors `json:"four_factors"`
+	}
+
+	// statsV2 names the three pointers fg3, rounds the percentages to a
+	// decimal and has the seconds played as a number. Counting stats are
+	// floats, for lines normalised with per.
+	statsV2 struct {
+		Minutes       string  `json:"min"`
+		SecondsPlayed float64 `json:"seconds_played"`
+		FGM           float64 `json:"fgm"`
+		FGA           float64 `json:"fga"`
+		FGP           float64 `json:"fgp"`
+		ThreeFGM      float64 `json:"fg3m"`
+		ThreeFGA      float64 `json:"fg3a"`
+		ThreeFGP      float64 `json:"fg3p"`
+		FTM           float64 `json:"ftm"`
+		FTA           float64 `json:"fta"`
+		FTP           float64 `json:"ftp"`
+		RO            float64 `json:"oreb"`
+		RD            float64 `json:"dreb"`
+		RT            float64 `json:"reb"`
+		RTeam         float64 `json:"rebt"`
+		AST           float64 `json:"ast"`
+		STL           float64 `json:"stl"`
+		BLK           float64 `json:"blk"`
+		TO            float64 `json:"to"`
+		TOT           float64 `json:"tot"`
+		FP            float64 `json:"pf"`
+		FD            float64 `json:"fd"`
+		PT            float64 `json:"pts"`
+		PlusMinus     float64 `json:"plus_minus"`
+	}
+)
+
+// newScoreboardV2 gives the start times in loc too, when it isn't nil
+func newScoreboardV2(sb stats.Scoreboard, loc *time.Location) scoreboardV2 {
+	v := scoreboardV2{Date: sb.Date, Games: make([]gameV2, len(sb.Games))}
+	if loc != nil {
+		v.Timezone = loc.String()
+	}
+
+	for i, g := range sb.Games {
+		v.Games[i] = gameV2{
+			ID:       g.ID,
+			Status:   g.Status,
+			StartsAt: time.Time(g.StartsAt).UTC().Format(time.RFC3339),
+			HomeTeam: teamRefV2{ID: g.HomeTeam.ID, Name: g.HomeTeam.Name, Tricode: g.HomeTeam.Tricode},
+			AwayTeam: teamRefV2{ID: g.AwayTeam.ID, Name: g.AwayTeam.Name, Tricode: g.AwayTeam.Tricode},
+		}
+
+		if loc != nil {
+			v.Games[i].StartsAtLocal = time.Time(g.StartsAt).In(loc).Format(time.RFC3339)
+		}
+	}
+
+	return v
+}
+
+// newBoxscoreV2 normalises the stat lines with per
+func newBoxscoreV2(b stats.Boxscore, per stats.Per) boxscoreV2 {
+	return boxscoreV2{
+		GameID:   b.GameID,
+		Status:   b.Status,
+		Per:      per,
+		HomeTeam: newTeamV2(b.HomeTeam, b.AwayTeam, per),
+		AwayTeam: newTeamV2(b.AwayTeam, b.HomeTeam, per),
+	}
+}
+
+func newTeamV2(t, opp stats.Team, per stats.Per) teamV2 {
+	v := teamV2{
+		ID:      t.ID,
+		Name:    t.Name,
+		Tricode: t.Tricode,
+		Stats:   newStatsV2(t.Stats, per.TeamFactor(t.Stats, opp.Stats)),
+		Players: make([]playerV2, len(t.Players)),
+	}
+
+	for i, p := range t.Players {
+		v.Players[i] = playerV2{
+			FirstName: p.FirstName,
+			LastName:  p.LastName,
+			Position:  p.Position,
+			Stats:     newStatsV2(p.Stats, per.PlayerFactor(p.Stats, t.Stats, opp.Stats)),
+		}
+	}
+
+	return v
+}
+
+func newComparisonV2(c stats.Comparison) comparisonV2 {
+	v := comparisonV2{
+		Season:        c.Season,
+		TeamA:         newSeasonLineV2(c.TeamA),
+		TeamB:         newSeasonLineV2(c.TeamB),
+		HeadToHead:    make([]matchupV2, len(c.HeadToHead)),
+		Differentials: differentialsV2(c.Differentials),
+		MissingGames:  append([]string{}, c.MissingGames...),
+	}
+
+	for i, m := range c.HeadToHead {
+		v.HeadToHead[i] = matchupV2{
+			GameID:     m.GameID,
+			Status:     m.Status,
+			StartsAt:   m.StartsAt.Format(time.RFC3339),
+			HomeTeamID: m.HomeTeamID,
+			TeamAScore: m.TeamAScore,
+			TeamBScore: m.TeamBScore,
+		}
+
+		if m.Differentials != nil {
+			d := differentialsV2(*m.Differentials)
+			v.HeadToHead[i].Differentials = &d
+		}
+	}
+
+	return v
+}
+
+func newSeasonLineV2(l stats.SeasonLine) seasonLineV2 {
+	return seasonLineV2{
+		ID:                  l.ID,
+		Name:                l.Name,
+		Tricode:             l.Tricode,
+		Games:               l.Games,
+		Wins:                l.Wins,
+		Losses:              l.Losses,
+		Totals:              newStatsV2(l.Totals, 1),
+		PerGame:             perGameV2(l.Totals, l.Games),
+		OpponentPerGame:     perGameV2(l.Opponent, l.Games),
+		FourFactors:         l.FourFactors,
+		OpponentFourFactors: l.OpponentFourFactors,
+	}
+}
+
+// perGameV2 averages a season line over its games, the minutes too
+func perGameV2(s stats.Stats, games int) statsV2 {
+	if games == 0 {
+		return newStatsV2(stats.Stats{Minutes: s.Minutes}, 0)
+	}
+
+	v := newStatsV2(s, 1/float64(games))
+	v.SecondsPlayed = stats.Round(s.SecondsPlayed/float64(games), 1)
+	v.Minutes = fmt.Sprintf("%d:%02d", int(v.SecondsPlayed)/60, int(v.SecondsPlayed)%60)
+
+	return v
+}
+
+// newStatsV2 multiplies the counting stats by factor, percentages are left as
+// they are
+func newStatsV2(s stats.Stats, factor float64) statsV2 {
+	scale := func(n int64) float64 { return stats.Round(float64(n)*factor, 1) }
+
+	return statsV2{
+		Minutes:       s.Minutes,
+		SecondsPlayed: s.SecondsPlayed,
+		FGM:           scale(s.FGM),
+		FGA:           scale(s.FGA),
+		FGP:           stats.Round(s.FGP, 1),
+		ThreeFGM:      scale(s.ThreeFGM),
+		ThreeFGA:      scale(s.ThreeFGA),
+		ThreeFGP:      stats.Round(s.ThreeFGP, 1),
+		FTM:           scale(s.FTM),
+		FTA:           scale(s.FTA),
+		FTP:           stats.Round(s.FTP, 1),
+		RO:            scale(s.RO),
+		RD:            scale(s.RD),
+		RT:            scale(s.RT),
+		RTeam:         scale(s.RTeam),
+		AST:           scale(s.AST),
+		STL:           scale(s.STL),
+		BLK:           scale(s.BLK),
+		TO:            scale(s.TO),
+		TOT:           scale(s.TOT),
+		FP:            scale(s.FP),
+		FD:            scale(s.FD),
+		PT:            scale(s.PT),
+		PlusMinus:     stats.Round(s.PlusMinus*factor, 1),
+	}
+}
@@ -0,0 +1,101 @@
+// Package logging carries a request scoped logger in the context, so every
+// line logged while serving a request can be tied back to it.
+package logging
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type loggerKey struct{}
+
+// WithLogger returns a copy of ctx carrying the logger
+func WithLogger(ctx context.Context, logger *zap.SugaredLogger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger of ctx, or the global zap logger when there
+// is none
+func FromContext(ctx context.Context) *zap.SugaredLogger {
+	if logger, ok := ctx.Value(loggerKey{}).(*zap.SugaredLogger); ok {
+		return logger
+	}
+
+	return zap.S()
+}
+
+const (
+	// FormatJSON writes a JSON object per line
+	FormatJSON = "json"
+	// FormatConsole writes human readable lines, for local runs
+	FormatConsole = "console"
+)
+
+// Config describes the logger to build
+type Config struct {
+	// Level is debug, info, warn or error. When empty it is info, or debug in
+	// debug mode.
+	Level string
+	// Format is json or console, json when empty.
+	Format string
+	// Sampling caps repeated lines to 100 per second, then 1 in 100.
+	Sampling bool
+	// Debug lowers the default level to debug.
+	Debug bool
+}
+
+// ParseLevel is the level of the config, from Level or Debug
+func (cfg Config) ParseLevel() (zapcore.Level, error) {
+	if cfg.Level == "" {
+		if cfg.Debug {
+			return zap.DebugLevel, nil
+		}
+
+		return zap.InfoLevel, nil
+	}
+
+	lvl, err := zapcore.ParseLevel(cfg.Level)
+	if err != nil {
+		return lvl, fmt.Errorf("invalid log level %q: %w", cfg.Level, err)
+	}
+
+	return lvl, nil
+}
+
+// New builds the logger from the config. Its level can be changed at runtime
+// through the returned AtomicLevel.
+func New(cfg Config) (*zap.Logger, zap.AtomicLevel, error) {
+	lvl, err := cfg.ParseLevel()
+	if err != nil {
+		return nil, zap.AtomicLevel{}, err
+	}
+
+	level := zap.NewAtomicLevelAt(lvl)
+
+	zc := zap.NewProductionConfig()
+	zc.Level = level
+	zc.Sampling = nil
+
+	switch cfg.Format {
+	case "", FormatJSON:
+	case FormatConsole:
+		zc.Encoding = FormatConsole
+		zc.EncoderConfig = zap.NewDevelopmentEncoderConfig()
+	default:
+		return nil, level, fmt.Errorf("invalid log format %q", cfg.Format)
+	}
+
+	if cfg.Sampling {
+		zc.Sampling = &zap.SamplingConfig{Initial: 100, Thereafter: 100}
+	}
+
+	l, err := zc.Build()
+	if err != nil {
+		return nil, level, fmt.Errorf("failed to build logger: %w", err)
+	}
+
+	return l, level, nil
+}
@@ -0,0 +1,63 @@
+package logging_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestFromContext(t *testing.T) {
+	t.Parallel()
+
+	logger := zap.NewNop().Sugar().With("request_id", "abc")
+
+	assert.Same(t, logger, logging.FromContext(logging.WithLogger(context.Background(), logger)))
+	assert.Same(t, zap.S(), logging.FromContext(context.Background()))
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		scenario string
+
+		cfg logging.Config
+
+		expLevel zapcore.Level
+		expErr   string
+	}{
+		{scenario: "defaults", expLevel: zap.InfoLevel},
+		{scenario: "debug mode", cfg: logging.Config{Debug: true}, expLevel: zap.DebugLevel},
+		{scenario: "level wins over debug mode", cfg: logging.Config{Debug: true, Level: "warn"}, expLevel: zap.WarnLevel},
+		{scenario: "console with sampling", cfg: logging.Config{Level: "error", Format: "console", Sampling: true}, expLevel: zap.ErrorLevel},
+		{scenario: "invalid level", cfg: logging.Config{Level: "loud"}, expErr: `invalid log level "loud"`},
+		{scenario: "invalid format", cfg: logging.Config{Format: "xml"}, expErr: `invalid log format "xml"`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			l, level, err := logging.New(tt.cfg)
+			if tt.expErr != "" {
+				assert.ErrorContains(t, err, tt.expErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expLevel, level.Level())
+
+			// The level is shared with the logger, for runtime changes.
+			level.SetLevel(zap.DebugLevel)
+			assert.True(t, l.Core().Enabled(zap.DebugLevel))
+		})
+	}
+}
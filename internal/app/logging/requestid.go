@@ -0,0 +1,22 @@
+package logging
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+)
+
+// validRequestID keeps ids set by callers short and safe to log
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestID is the id the caller sent when it is safe to log, or a new one
+func RequestID(id string) string {
+	if validRequestID.MatchString(id) {
+		return id
+	}
+
+	b := make([]byte, 16)
+	rand.Read(b) //nolint: errcheck
+
+	return hex.EncodeToString(b)
+}
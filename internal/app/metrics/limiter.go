@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	limiterRequests = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "upstream_limiter", "requests_total"),
+		"Requests that went through the upstream limiter, by host and priority.",
+		[]string{"host", "priority"}, nil,
+	)
+
+	limiterQueued = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "upstream_limiter", "queued_total"),
+		"Requests that had to queue for an upstream token, by host and priority.",
+		[]string{"host", "priority"}, nil,
+	)
+
+	limiterWait = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "upstream_limiter", "wait_seconds"),
+		"Time requests spent queued for an upstream token, by host and priority.",
+		[]string{"host", "priority"}, nil,
+	)
+)
+
+// limiterCollector reads the wait stats of the limiter on every scrape
+type limiterCollector struct {
+	l *gateway.Limiter
+}
+
+func (c *limiterCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- limiterRequests
+	ch <- limiterQueued
+	ch <- limiterWait
+}
+
+func (c *limiterCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, s := range c.l.Stats() {
+		labels := []string{s.Host, s.Priority.String()}
+
+		ch <- prometheus.MustNewConstMetric(limiterRequests, prometheus.CounterValue, float64(s.Requests), labels...)
+		ch <- prometheus.MustNewConstMetric(limiterQueued, prometheus.CounterValue, float64(s.Queued), labels...)
+		ch <- prometheus.MustNewConstHistogram(limiterWait, uint64(s.Requests), s.Total.Seconds(), waitBuckets(s), labels...)
+	}
+}
+
+// waitBuckets are the cumulative counts of the wait histogram
+func waitBuckets(s gateway.WaitStats) map[float64]uint64 {
+	var (
+		buckets = make(map[float64]uint64, len(gateway.WaitBuckets))
+		n       uint64
+	)
+
+	for i, b := range gateway.WaitBuckets {
+		n += uint64(s.Waits[i])
+		buckets[b.Seconds()] = n
+	}
+
+	return buckets
+}
@@ -0,0 +1,208 @@
+// Package metrics holds the Prometheus metrics of the API and the upstream
+// calls, and the handler to scrape them.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "nba_stats"
+
+const (
+	// EndpointScoreboard is the stats.nba.com scoreboardv3 endpoint
+	EndpointScoreboard = "scoreboardv3"
+	// EndpointBoxscore is the liveData CDN boxscore endpoint
+	EndpointBoxscore = "boxscore"
+	// EndpointSchedule is the staticData CDN schedule of the season
+	EndpointSchedule = "schedule"
+
+	// CacheStorage is the store of finished games
+	CacheStorage = "storage"
+	// CacheBoxscoreETag is the boxscore revalidated with the CDN ETag
+	CacheBoxscoreETag = "boxscore_etag"
+
+	// ReasonRequest is an upstream that couldn't be reached
+	ReasonRequest = "request"
+	// ReasonStatus is an upstream answering with an error status
+	ReasonStatus = "status"
+	// ReasonDecode is an upstream answering with a body that can't be decoded
+	ReasonDecode = "decode"
+)
+
+var (
+	// Registry has every metric of the API, plus the Go runtime and process ones
+	Registry = prometheus.NewRegistry()
+
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "HTTP requests by route pattern, method and status code.",
+	}, []string{"route", "method", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by route pattern, method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	httpInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_in_flight",
+		Help:      "HTTP requests being served.",
+	})
+
+	grpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "requests_total",
+		Help:      "gRPC calls by method and status code.",
+	}, []string{"method", "code"})
+
+	grpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "request_duration_seconds",
+		Help:      "gRPC call latency by method and status code. Streams last until they end.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	upstreamDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "upstream",
+		Name:      "request_duration_seconds",
+		Help:      "Upstream call latency by endpoint and league, after the rate limiter wait.",
+		Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"endpoint", "league"})
+
+	upstreamErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "upstream",
+		Name:      "errors_total",
+		Help:      "Failed upstream calls by endpoint, league and reason (request, status or decode).",
+	}, []string{"endpoint", "league", "reason"})
+
+	decodeFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "upstream",
+		Name:      "decode_failures_total",
+		Help:      "Upstream responses whose body couldn't be decoded, by endpoint and league.",
+	}, []string{"endpoint", "league"})
+
+	cacheLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "lookups_total",
+		Help:      "Cache lookups by cache and result (hit or miss).",
+	}, []string{"cache", "result"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		httpInFlight,
+		grpcRequests,
+		grpcDuration,
+		upstreamDuration,
+		upstreamErrors,
+		decodeFailures,
+		cacheLookups,
+	)
+}
+
+// Handler serves the metrics in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// RequestStarted counts a request in flight until the returned func is called
+// with its route pattern and status code.
+func RequestStarted(method string) func(route string, status int) {
+	start := time.Now()
+
+	httpInFlight.Inc()
+
+	method = methodLabel(method)
+
+	return func(route string, status int) {
+		httpInFlight.Dec()
+
+		code := statusLabel(status)
+		httpRequests.WithLabelValues(route, method, code).Inc()
+		httpDuration.WithLabelValues(route, method, code).Observe(time.Since(start).Seconds())
+	}
+}
+
+// CallStarted times a gRPC call until the returned func is called with its
+// status code, like OK or Unavailable
+func CallStarted(method string) func(code string) {
+	start := time.Now()
+
+	return func(code string) {
+		grpcRequests.WithLabelValues(method, code).Inc()
+		grpcDuration.WithLabelValues(method, code).Observe(time.Since(start).Seconds())
+	}
+}
+
+// ObserveUpstream records the latency of an upstream call
+func ObserveUpstream(endpoint, league string, d time.Duration) {
+	upstreamDuration.WithLabelValues(endpoint, league).Observe(d.Seconds())
+}
+
+// UpstreamFailed counts a failed upstream call. Decode failures are also
+// counted on their own.
+func UpstreamFailed(endpoint, league, reason string) {
+	upstreamErrors.WithLabelValues(endpoint, league, reason).Inc()
+
+	if reason == ReasonDecode {
+		decodeFailures.WithLabelValues(endpoint, league).Inc()
+	}
+}
+
+// CacheLookup counts a hit or a miss of the cache
+func CacheLookup(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+
+	cacheLookups.WithLabelValues(cache, result).Inc()
+}
+
+// RegisterLimiter exposes the wait stats of the upstream limiter
+func RegisterLimiter(l *gateway.Limiter) error {
+	return Registry.Register(&limiterCollector{l: l})
+}
+
+// methodLabel keeps the methods of RFC 9110 and PATCH, any other being
+// "other", so clients can't grow the label without bound
+func methodLabel(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return method
+	default:
+		return "other"
+	}
+}
+
+func statusLabel(status int) string {
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	return strconv.Itoa(status)
+}
@@ -0,0 +1,60 @@
+package metrics_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/metrics"
+	"github.com/stretchr/testify/suite"
+)
+
+type MetricsTestSuite struct {
+	suite.Suite
+}
+
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(MetricsTestSuite))
+}
+
+func (s *MetricsTestSuite) scrape() string {
+	w := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	s.Require().Equal(http.StatusOK, w.Code)
+
+	return w.Body.String()
+}
+
+func (s *MetricsTestSuite) TestUpstream() {
+	metrics.UpstreamFailed(metrics.EndpointBoxscore, "wnba", metrics.ReasonDecode)
+	metrics.CacheLookup(metrics.CacheStorage, true)
+
+	body := s.scrape()
+	s.Contains(body, `nba_stats_upstream_errors_total{endpoint="boxscore",league="wnba",reason="decode"} 1`)
+	s.Contains(body, `nba_stats_upstream_decode_failures_total{endpoint="boxscore",league="wnba"} 1`)
+	s.Contains(body, `nba_stats_cache_lookups_total{cache="storage",result="hit"} 1`)
+}
+
+func (s *MetricsTestSuite) TestLimiter() {
+	l := gateway.NewLimiter(map[string]gateway.HostLimit{"stats.nba.test": {PerSecond: 1, Burst: 1}})
+	s.Require().NoError(l.Wait(context.Background(), "stats.nba.test"))
+	s.Require().NoError(metrics.RegisterLimiter(l))
+
+	body := s.scrape()
+	s.Contains(body, `nba_stats_upstream_limiter_requests_total{host="stats.nba.test",priority="interactive"} 1`)
+	s.Contains(body, `nba_stats_upstream_limiter_queued_total{host="stats.nba.test",priority="interactive"} 0`)
+	s.Contains(body, `nba_stats_upstream_limiter_wait_seconds_bucket{host="stats.nba.test",priority="interactive",le="0"} 1`)
+	s.Contains(body, `nba_stats_upstream_limiter_wait_seconds_count{host="stats.nba.test",priority="interactive"} 1`)
+}
+
+func (s *MetricsTestSuite) TestCall() {
+	metrics.CallStarted("/nba.stats.v1.StatsService/GetBoxscore")("Unavailable")
+
+	body := s.scrape()
+	s.Contains(body, `nba_stats_grpc_requests_total{code="Unavailable",method="/nba.stats.v1.StatsService/GetBoxscore"} 1`)
+	s.Contains(body, `nba_stats_grpc_request_duration_seconds_count{code="Unavailable",method="/nba.stats.v1.StatsService/GetBoxscore"} 1`)
+}
@@ -0,0 +1,416 @@
+// Package ratelimit authenticates API keys and limits every client of the
+// APIs with its own token bucket, whatever the transport.
+package ratelimit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// idleBucket is how long a client bucket is kept after its last request. By
+// then it is full again, so dropping it changes nothing.
+const idleBucket = 10 * time.Minute
+
+type (
+	// Tier is the token bucket shared by a class of clients
+	Tier struct {
+		// PerMinute is the rate tokens are added at. Zero means no limit.
+		PerMinute int `json:"per_minute"`
+		Burst     int `json:"burst"`
+	}
+
+	// APIKey identifies a client and the tier it is limited by
+	APIKey struct {
+		Key    string `json:"key"`
+		Client string `json:"client"`
+		Tier   string `json:"tier"`
+	}
+
+	// Limits configures the Limiter
+	Limits struct {
+		// Anonymous limits requests without an API key, per IP
+		Anonymous Tier            `json:"anonymous"`
+		Tiers     map[string]Tier `json:"tiers"`
+		Keys      []APIKey        `json:"keys"`
+		// ClientIPHeader is the header the proxy in front of the API sets with
+		// the client IP, a single address or a X-Forwarded-For list. The
+		// connection address is used when empty.
+		ClientIPHeader string `json:"client_ip_header"`
+		// TrustedProxies are the addresses or CIDRs of the proxies allowed to
+		// set ClientIPHeader. It is ignored on connections from anyone else.
+		TrustedProxies []string `json:"trusted_proxies"`
+	}
+
+	// Limiter authenticates API keys and limits every client with its own
+	// token bucket
+	Limiter struct {
+		now func() time.Time
+
+		mu        sync.Mutex
+		limits    Limits
+		keys      map[string]APIKey
+		proxies   []netip.Prefix
+		buckets   map[string]*bucket
+		lastSweep time.Time
+	}
+
+	// Option configures the Limiter
+	Option func(*Limiter)
+
+	bucket struct {
+		tier    Tier
+		limiter *rate.Limiter
+		seen    time.Time
+	}
+
+	// Admission is what the Limiter decided for a request
+	Admission struct {
+		// Client is key:<client> for API keys, ip:<address> otherwise
+		Client string
+		// UnknownKey is set for API keys that don't exist
+		UnknownKey bool
+		// Limited is unset for unlimited tiers, which have no other field set
+		Limited    bool
+		Allowed    bool
+		Limit      int
+		Remaining  int
+		Reset      time.Duration
+		RetryAfter time.Duration
+
+		tier Tier
+	}
+)
+
+// DefaultLimits are the tiers available to API keys, and the limit of
+// anonymous clients
+func DefaultLimits() Limits {
+	return Limits{
+		Anonymous: Tier{PerMinute: 60, Burst: 10},
+		Tiers: map[string]Tier{
+			"standard": {PerMinute: 600, Burst: 60},
+			"partner":  {PerMinute: 3000, Burst: 300},
+		},
+	}
+}
+
+// LoadLimits reads tiers and keys from a JSON file and adds them to base.
+// Tiers in the file replace the ones with the same name.
+func LoadLimits(path string, base Limits) (Limits, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Limits{}, fmt.Errorf("failed to read rate limits: %w", err)
+	}
+
+	var file Limits
+	if err := json.Unmarshal(data, &file); err != nil {
+		return Limits{}, fmt.Errorf("failed to decode rate limits %s: %w", path, err)
+	}
+
+	tiers := make(map[string]Tier, len(base.Tiers)+len(file.Tiers))
+	for name, t := range base.Tiers {
+		tiers[name] = t
+	}
+
+	for name, t := range file.Tiers {
+		tiers[name] = t
+	}
+
+	base.Tiers = tiers
+	base.Keys = append(append([]APIKey{}, base.Keys...), file.Keys...)
+
+	if file.Anonymous != (Tier{}) {
+		base.Anonymous = file.Anonymous
+	}
+
+	if file.ClientIPHeader != "" {
+		base.ClientIPHeader = file.ClientIPHeader
+	}
+
+	base.TrustedProxies = append(append([]string{}, base.TrustedProxies...), file.TrustedProxies...)
+
+	return base, nil
+}
+
+// ParseAPIKey parses a key in the client:tier:key format
+func ParseAPIKey(s string) (APIKey, error) {
+	parts := strings.SplitN(s, ":", 3)
+	if len(parts) != 3 {
+		return APIKey{}, errors.New("api key must be in the client:tier:key format")
+	}
+
+	return APIKey{Client: parts[0], Tier: parts[1], Key: parts[2]}, nil
+}
+
+// Validate checks tiers have a burst, every key has a client, is unique and
+// uses a known tier, and the trusted proxies are addresses or CIDRs
+func (l Limits) Validate() error {
+	if _, err := parseProxies(l.TrustedProxies); err != nil {
+		return err
+	}
+
+	if l.Anonymous.PerMinute > 0 && l.Anonymous.Burst < 1 {
+		return errors.New("anonymous tier needs a burst")
+	}
+
+	for name, t := range l.Tiers {
+		if t.PerMinute > 0 && t.Burst < 1 {
+			return fmt.Errorf("tier %s needs a burst", name)
+		}
+	}
+
+	seen := make(map[string]bool, len(l.Keys))
+
+	for _, k := range l.Keys {
+		switch {
+		case k.Key == "" || k.Client == "":
+			return errors.New("api keys need a key and a client")
+		case seen[k.Key]:
+			return fmt.Errorf("api key of %s is duplicated", k.Client)
+		}
+
+		if _, ok := l.Tiers[k.Tier]; !ok {
+			return fmt.Errorf("api key of %s has unknown tier %q", k.Client, k.Tier)
+		}
+
+		seen[k.Key] = true
+	}
+
+	return nil
+}
+
+// WithClock replaces time.Now, for tests to control the buckets
+func WithClock(now func() time.Time) Option {
+	return func(rl *Limiter) { rl.now = now }
+}
+
+// NewLimiter creates a Limiter enforcing the limits
+func NewLimiter(l Limits, opts ...Option) (*Limiter, error) {
+	rl := &Limiter{now: time.Now, buckets: make(map[string]*bucket)}
+
+	for _, opt := range opts {
+		opt(rl)
+	}
+
+	if err := rl.Update(l); err != nil {
+		return nil, err
+	}
+
+	return rl, nil
+}
+
+// Update replaces the limits. Clients keep their buckets unless their tier
+// changed.
+func (rl *Limiter) Update(l Limits) error {
+	if err := l.Validate(); err != nil {
+		return err
+	}
+
+	keys := make(map[string]APIKey, len(l.Keys))
+	for _, k := range l.Keys {
+		keys[k.Key] = k
+	}
+
+	proxies, _ := parseProxies(l.TrustedProxies)
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.limits, rl.keys, rl.proxies = l, keys, proxies
+
+	return nil
+}
+
+// Admit identifies the client of a request by its API key, or by its IP
+// without one, and spends a token of its bucket
+func (rl *Limiter) Admit(key, ip string) Admission {
+	client, tier, ok := rl.identify(key, ip)
+	if !ok {
+		return Admission{UnknownKey: true}
+	}
+
+	if tier.PerMinute <= 0 {
+		return Admission{Client: client}
+	}
+
+	allowed, remaining, reset, retry := rl.take(client, tier)
+
+	return Admission{
+		Client:     client,
+		Limited:    true,
+		Allowed:    allowed,
+		Limit:      tier.Burst,
+		Remaining:  remaining,
+		Reset:      reset,
+		RetryAfter: retry,
+		tier:       tier,
+	}
+}
+
+// Charge spends another token of an admitted client, for requests that cost
+// more than one. It is false once there are none left.
+func (rl *Limiter) Charge(ad Admission) bool {
+	if !ad.Limited {
+		return true
+	}
+
+	allowed, _, _, _ := rl.take(ad.Client, ad.tier)
+
+	return allowed
+}
+
+// ClientIPHeader is the header the proxy sets with the client IP, if any
+func (rl *Limiter) ClientIPHeader() string {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	return rl.limits.ClientIPHeader
+}
+
+// ClientIP is the address a request is limited by. That is the connection
+// address peer, unless it is a trusted proxy setting ClientIPHeader, whose
+// value is forwarded. Hops are read from the right, the first one that isn't a
+// trusted proxy is the client: whatever is left of it was sent by the client
+// and can't be trusted.
+func (rl *Limiter) ClientIP(peer, forwarded string) string {
+	if host, _, err := net.SplitHostPort(peer); err == nil {
+		peer = host
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	if rl.limits.ClientIPHeader == "" || forwarded == "" || !rl.trusted(peer) {
+		return peer
+	}
+
+	hops := strings.Split(forwarded, ",")
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if _, err := netip.ParseAddr(hop); err != nil {
+			break
+		}
+
+		if !rl.trusted(hop) || i == 0 {
+			return hop
+		}
+	}
+
+	return peer
+}
+
+// trusted is whether ip is one of the trusted proxies
+func (rl *Limiter) trusted(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+
+	addr = addr.Unmap()
+
+	for _, p := range rl.proxies {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// identify returns the client of the request and its tier. It fails when the
+// API key is unknown.
+func (rl *Limiter) identify(key, ip string) (string, Tier, bool) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	if key != "" {
+		k, ok := rl.keys[key]
+		if !ok {
+			return "", Tier{}, false
+		}
+
+		return "key:" + k.Client, rl.limits.Tiers[k.Tier], true
+	}
+
+	return "ip:" + ip, rl.limits.Anonymous, true
+}
+
+// take spends a token of the client bucket. It returns whether there was one,
+// the tokens left, when the bucket is full again and, when there wasn't one,
+// when the next one is.
+func (rl *Limiter) take(client string, tier Tier) (bool, int, time.Duration, time.Duration) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := rl.now()
+	rl.sweep(now)
+
+	b, ok := rl.buckets[client]
+	if !ok || b.tier != tier {
+		b = &bucket{tier: tier, limiter: rate.NewLimiter(rate.Limit(float64(tier.PerMinute)/60), tier.Burst)}
+		rl.buckets[client] = b
+	}
+
+	b.seen = now
+
+	var (
+		res     = b.limiter.ReserveN(now, 1)
+		retry   = res.DelayFrom(now)
+		allowed = retry == 0
+	)
+
+	if !allowed {
+		res.CancelAt(now)
+	}
+
+	tokens := b.limiter.TokensAt(now)
+	reset := time.Duration((float64(tier.Burst) - tokens) / float64(b.limiter.Limit()) * float64(time.Second))
+
+	return allowed, max(int(tokens), 0), reset, retry
+}
+
+// sweep drops the buckets of clients that went idle
+func (rl *Limiter) sweep(now time.Time) {
+	if now.Sub(rl.lastSweep) < idleBucket {
+		return
+	}
+
+	for client, b := range rl.buckets {
+		if now.Sub(b.seen) > idleBucket {
+			delete(rl.buckets, client)
+		}
+	}
+
+	rl.lastSweep = now
+}
+
+// parseProxies parses addresses and CIDRs into prefixes
+func parseProxies(proxies []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(proxies))
+
+	for _, p := range proxies {
+		if addr, err := netip.ParseAddr(p); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(p)
+		if err != nil {
+			return nil, fmt.Errorf("trusted proxy %q is not an address or a CIDR", p)
+		}
+
+		prefixes = append(prefixes, prefix.Masked())
+	}
+
+	return prefixes, nil
+}
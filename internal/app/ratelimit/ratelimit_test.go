@@ -0,0 +1,105 @@
+package ratelimit_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdmit(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 10, 18, 23, 30, 0, 0, time.UTC)
+
+	rl, err := ratelimit.NewLimiter(ratelimit.Limits{
+		Anonymous: ratelimit.Tier{PerMinute: 60, Burst: 2},
+		Tiers:     map[string]ratelimit.Tier{"internal": {}},
+		Keys:      []ratelimit.APIKey{{Key: "internal", Client: "frontend", Tier: "internal"}},
+	}, ratelimit.WithClock(func() time.Time { return now }))
+	require.NoError(t, err)
+
+	ad := rl.Admit("", "192.0.2.1")
+	assert.Equal(t, "ip:192.0.2.1", ad.Client)
+	assert.True(t, ad.Limited)
+	assert.True(t, ad.Allowed)
+	assert.Equal(t, 1, ad.Remaining)
+
+	// The admission charges the bucket for the calls past the first.
+	assert.True(t, rl.Charge(ad))
+	assert.False(t, rl.Charge(ad))
+	assert.False(t, rl.Admit("", "192.0.2.1").Allowed)
+
+	// Unlimited tiers are never charged.
+	ad = rl.Admit("internal", "192.0.2.1")
+	assert.False(t, ad.Limited)
+	assert.True(t, rl.Charge(ad))
+
+	assert.True(t, rl.Admit("unknown", "192.0.2.1").UnknownKey)
+}
+
+func TestLoadLimits(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "keys.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"tiers": {"partner": {"per_minute": 100, "burst": 20}},
+		"keys": [{"key": "secret", "client": "celtics", "tier": "partner"}]
+	}`), 0o600))
+
+	l, err := ratelimit.LoadLimits(path, ratelimit.DefaultLimits())
+	require.NoError(t, err)
+	assert.NoError(t, l.Validate())
+	assert.Equal(t, ratelimit.Tier{PerMinute: 100, Burst: 20}, l.Tiers["partner"])
+	assert.Contains(t, l.Tiers, "standard")
+	assert.Equal(t, ratelimit.DefaultLimits().Anonymous, l.Anonymous)
+	assert.Equal(t, []ratelimit.APIKey{{Key: "secret", Client: "celtics", Tier: "partner"}}, l.Keys)
+
+	k, err := ratelimit.ParseAPIKey("lakers:standard:abc:def")
+	require.NoError(t, err)
+	assert.Equal(t, ratelimit.APIKey{Key: "abc:def", Client: "lakers", Tier: "standard"}, k)
+
+	_, err = ratelimit.ParseAPIKey("lakers")
+	assert.Error(t, err)
+}
+
+func TestClientIP(t *testing.T) {
+	t.Parallel()
+
+	rl, err := ratelimit.NewLimiter(ratelimit.Limits{
+		ClientIPHeader: "X-Forwarded-For",
+		TrustedProxies: []string{"10.0.0.0/8", "192.0.2.1"},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		scenario  string
+		peer      string
+		forwarded string
+		exp       string
+	}{
+		{scenario: "no header", peer: "192.0.2.1:1234", exp: "192.0.2.1"},
+		{scenario: "untrusted peer", peer: "203.0.113.7:1234", forwarded: "198.51.100.1", exp: "203.0.113.7"},
+		{scenario: "trusted peer", peer: "192.0.2.1:1234", forwarded: "198.51.100.1", exp: "198.51.100.1"},
+		{scenario: "spoofed hops", peer: "192.0.2.1:1234", forwarded: "198.51.100.1, 203.0.113.7", exp: "203.0.113.7"},
+		{scenario: "trusted hops", peer: "192.0.2.1:1234", forwarded: "203.0.113.7, 10.1.2.3", exp: "203.0.113.7"},
+		{scenario: "invalid hop", peer: "192.0.2.1:1234", forwarded: "unknown", exp: "192.0.2.1"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.exp, rl.ClientIP(tt.peer, tt.forwarded))
+		})
+	}
+
+	_, err = ratelimit.NewLimiter(ratelimit.Limits{TrustedProxies: []string{"proxy"}})
+	assert.Error(t, err)
+}
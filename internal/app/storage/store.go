@@ -0,0 +1,178 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/metrics"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	scoreboardsBucket = []byte("scoreboards")
+	boxscoresBucket   = []byte("boxscores")
+)
+
+type (
+	// Store persists upstream data for games that are already over
+	Store interface {
+		GetScoreboard(context.Context, nba.GetScoreboardCommand) (nba.ScoreboardData, bool, error)
+		PutScoreboard(context.Context, nba.GetScoreboardCommand, nba.ScoreboardData) error
+		GetBoxscore(context.Context, nba.GetBoxscoreCommand) (nba.BoxscoreData, bool, error)
+		PutBoxscore(context.Context, nba.GetBoxscoreCommand, nba.BoxscoreData) error
+	}
+
+	// Bolt is a Store backed by an embedded bbolt database
+	Bolt struct {
+		db *bolt.DB
+	}
+
+	// Noop is a Store that never holds anything
+	Noop struct{}
+)
+
+// Open opens, or creates, the bbolt database at path
+func Open(path string) (*Bolt, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, b := range [][]byte{scoreboardsBucket, boxscoresBucket} {
+			if _, err := tx.CreateBucketIfNotExists(b); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		db.Close()
+
+		return nil, fmt.Errorf("failed to create buckets: %w", err)
+	}
+
+	return &Bolt{db: db}, nil
+}
+
+// Close releases the database file
+func (b *Bolt) Close() error {
+	return b.db.Close()
+}
+
+// Check fails when the database can't be read
+func (b *Bolt) Check(context.Context) error {
+	return b.db.View(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{scoreboardsBucket, boxscoresBucket} {
+			if tx.Bucket(name) == nil {
+				return fmt.Errorf("bucket %s is missing", name)
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetScoreboard returns the stored scoreboard for the command date, if any
+func (b *Bolt) GetScoreboard(_ context.Context, cmd nba.GetScoreboardCommand) (nba.ScoreboardData, bool, error) {
+	var sb nba.ScoreboardData
+
+	ok, err := b.get(scoreboardsBucket, scoreboardKey(cmd), &sb)
+
+	return sb, ok, err
+}
+
+// PutScoreboard stores the scoreboard for the command date
+func (b *Bolt) PutScoreboard(_ context.Context, cmd nba.GetScoreboardCommand, sb nba.ScoreboardData) error {
+	return b.put(scoreboardsBucket, scoreboardKey(cmd), sb)
+}
+
+// GetBoxscore returns the stored boxscore for the command game, if any
+func (b *Bolt) GetBoxscore(_ context.Context, cmd nba.GetBoxscoreCommand) (nba.BoxscoreData, bool, error) {
+	var bs nba.BoxscoreData
+
+	ok, err := b.get(boxscoresBucket, boxscoreKey(cmd), &bs)
+
+	return bs, ok, err
+}
+
+// PutBoxscore stores the boxscore for the command game
+func (b *Bolt) PutBoxscore(_ context.Context, cmd nba.GetBoxscoreCommand, bs nba.BoxscoreData) error {
+	return b.put(boxscoresBucket, boxscoreKey(cmd), bs)
+}
+
+func (b *Bolt) get(bucket, key []byte, v any) (bool, error) {
+	var data []byte
+
+	err := b.db.View(func(tx *bolt.Tx) error {
+		if d := tx.Bucket(bucket).Get(key); d != nil {
+			data = append(data, d...)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", key, err)
+	}
+
+	metrics.CacheLookup(metrics.CacheStorage, data != nil)
+
+	if data == nil {
+		return false, nil
+	}
+
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(v); err != nil {
+		return false, fmt.Errorf("failed to decode %s: %w", key, err)
+	}
+
+	return true, nil
+}
+
+func (b *Bolt) put(bucket, key []byte, v any) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).Put(key, buf.Bytes())
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+
+	return nil
+}
+
+func scoreboardKey(cmd nba.GetScoreboardCommand) []byte {
+	return []byte(fmt.Sprintf("%s/%s", cmd.LeagueID, cmd.Date))
+}
+
+func boxscoreKey(cmd nba.GetBoxscoreCommand) []byte {
+	return []byte(fmt.Sprintf("%s/%s", cmd.LeagueID, cmd.GameID))
+}
+
+// GetScoreboard never finds anything
+func (Noop) GetScoreboard(context.Context, nba.GetScoreboardCommand) (nba.ScoreboardData, bool, error) {
+	return nba.ScoreboardData{}, false, nil
+}
+
+// PutScoreboard discards the scoreboard
+func (Noop) PutScoreboard(context.Context, nba.GetScoreboardCommand, nba.ScoreboardData) error {
+	return nil
+}
+
+// GetBoxscore never finds anything
+func (Noop) GetBoxscore(context.Context, nba.GetBoxscoreCommand) (nba.BoxscoreData, bool, error) {
+	return nba.BoxscoreData{}, false, nil
+}
+
+// PutBoxscore discards the boxscore
+func (Noop) PutBoxscore(context.Context, nba.GetBoxscoreCommand, nba.BoxscoreData) error {
+	return nil
+}
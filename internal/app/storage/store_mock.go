@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/stretchr/testify/mock"
+)
+
+// StoreMock mock
+type StoreMock struct{ mock.Mock }
+
+// GetScoreboard mock
+func (m *StoreMock) GetScoreboard(ctx context.Context, cmd nba.GetScoreboardCommand) (nba.ScoreboardData, bool, error) {
+	args := m.Called(ctx, cmd)
+
+	return args.Get(0).(nba.ScoreboardData), args.Bool(1), args.Error(2)
+}
+
+// PutScoreboard mock
+func (m *StoreMock) PutScoreboard(ctx context.Context, cmd nba.GetScoreboardCommand, sb nba.ScoreboardData) error {
+	args := m.Called(ctx, cmd, sb)
+
+	return args.Error(0)
+}
+
+// GetBoxscore mock
+func (m *StoreMock) GetBoxscore(ctx context.Context, cmd nba.GetBoxscoreCommand) (nba.BoxscoreData, bool, error) {
+	args := m.Called(ctx, cmd)
+
+	return args.Get(0).(nba.BoxscoreData), args.Bool(1), args.Error(2)
+}
+
+// PutBoxscore mock
+func (m *StoreMock) PutBoxscore(ctx context.Context, cmd nba.GetBoxscoreCommand, bs nba.BoxscoreData) error {
+	args := m.Called(ctx, cmd, bs)
+
+	return args.Error(0)
+}
@@ -0,0 +1,108 @@
+package storage_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/gateway/nba"
+	"github.com/pedro-mealha/nba-stats-api/internal/app/storage"
+	"github.com/stretchr/testify/suite"
+)
+
+type BoltTestSuite struct {
+	suite.Suite
+
+	b *storage.Bolt
+}
+
+func (s *BoltTestSuite) SetupTest() {
+	b, err := storage.Open(filepath.Join(s.T().TempDir(), "nba.db"))
+	s.Require().NoError(err)
+
+	s.b = b
+}
+
+func (s *BoltTestSuite) TearDownTest() {
+	s.NoError(s.b.Close())
+}
+
+func TestBoltStore(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(BoltTestSuite))
+}
+
+func (s *BoltTestSuite) TestScoreboard() {
+	var (
+		ctx = context.Background()
+		cmd = nba.GetScoreboardCommand{Date: "2022-10-18", LeagueID: nba.NBA}
+
+		sb = nba.ScoreboardData{
+			Scoreboard: nba.Scoreboard{
+				GameDate: nba.GameDate(time.Date(2022, 10, 18, 0, 0, 0, 0, time.UTC)),
+				Games: []nba.Game{
+					{
+						ID:       "0022200001",
+						Status:   nba.Final,
+						StartsAt: nba.GameTime(time.Date(2022, 10, 18, 23, 30, 0, 0, time.UTC)),
+						HomeTeam: nba.Team{ID: 1610612738, Name: "Celtics", Tricode: "BOS"},
+						AwayTeam: nba.Team{ID: 1610612755, Name: "76ers", Tricode: "PHI"},
+					},
+				},
+			},
+		}
+	)
+
+	_, ok, err := s.b.GetScoreboard(ctx, cmd)
+	s.NoError(err)
+	s.False(ok)
+
+	s.NoError(s.b.PutScoreboard(ctx, cmd, sb))
+
+	res, ok, err := s.b.GetScoreboard(ctx, cmd)
+	s.NoError(err)
+	s.True(ok)
+	s.Equal(sb, res)
+
+	_, ok, err = s.b.GetScoreboard(ctx, nba.GetScoreboardCommand{Date: cmd.Date, LeagueID: nba.WNBA})
+	s.NoError(err)
+	s.False(ok)
+}
+
+func (s *BoltTestSuite) TestBoxscore() {
+	var (
+		ctx = context.Background()
+		cmd = nba.GetBoxscoreCommand{GameID: "0022200001", LeagueID: nba.NBA}
+
+		bs = nba.BoxscoreData{
+			Boxscore: nba.Boxscore{
+				ID:     "0022200001",
+				Status: nba.Final,
+				HomeTeam: nba.Team{
+					ID:    1610612738,
+					Stats: nba.Stats{Minutes: "PT240M00.00S", PT: 126, FGP: 0.48},
+					Players: []nba.Player{
+						{FirstName: "Jayson", LastName: "Tatum", Stats: nba.Stats{Minutes: "PT36M12.00S", PT: 35}},
+					},
+				},
+			},
+		}
+	)
+
+	_, ok, err := s.b.GetBoxscore(ctx, cmd)
+	s.NoError(err)
+	s.False(ok)
+
+	s.NoError(s.b.PutBoxscore(ctx, cmd, bs))
+
+	res, ok, err := s.b.GetBoxscore(ctx, cmd)
+	s.NoError(err)
+	s.True(ok)
+	s.Equal(bs, res)
+}
+
+func (s *BoltTestSuite) TestCheck() {
+	s.NoError(s.b.Check(context.Background()))
+}
@@ -0,0 +1,90 @@
+// Package tracing sets up OpenTelemetry: the tracer provider, its exporter and
+// the propagation of trace context between services.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// ExporterOTLP sends spans over OTLP/gRPC, configured with the standard
+	// OTEL_EXPORTER_OTLP_* env vars
+	ExporterOTLP Exporter = "otlp"
+	// ExporterStdout writes spans to stdout, for local debugging
+	ExporterStdout Exporter = "stdout"
+	// ExporterNone records nothing, only propagating the incoming trace context
+	ExporterNone Exporter = "none"
+)
+
+// Exporter selects where spans are sent
+type Exporter string
+
+// Setup installs the global tracer provider with the exporter, and the W3C
+// tracecontext and b3 propagators. The returned func flushes the pending spans
+// and must be called before exiting.
+func Setup(ctx context.Context, exporter Exporter, service string) (func(context.Context) error, error) {
+	// b3 is still what some of our callers send, so it is read and written
+	// alongside tracecontext.
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+		b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader)),
+	))
+
+	var (
+		exp sdktrace.SpanExporter
+		err error
+	)
+
+	switch exporter {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		exp, err = otlptracegrpc.New(ctx)
+	case ExporterStdout:
+		exp, err = stdouttrace.New()
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", exporter)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s exporter: %w", exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(service)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.AlwaysSample())),
+	)
+
+	otel.SetTracerProvider(tp)
+
+	return tp.Shutdown, nil
+}
+
+// End ends the span, marking it as failed when err isn't nil
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
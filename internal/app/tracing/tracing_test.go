@@ -0,0 +1,79 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/pedro-mealha/nba-stats-api/internal/app/tracing"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	spanID  = "00f067aa0ba902b7"
+)
+
+type TracingTestSuite struct {
+	suite.Suite
+}
+
+func TestTracing(t *testing.T) {
+	suite.Run(t, new(TracingTestSuite))
+}
+
+func (s *TracingTestSuite) TestPropagation() {
+	shutdown, err := tracing.Setup(context.Background(), tracing.ExporterNone, "nba-stats-api")
+	s.Require().NoError(err)
+	s.NoError(shutdown(context.Background()))
+
+	tests := []struct {
+		scenario string
+		header   http.Header
+	}{
+		{
+			scenario: "tracecontext",
+			header:   http.Header{"Traceparent": {"00-" + traceID + "-" + spanID + "-01"}},
+		},
+		{
+			scenario: "b3 single header",
+			header:   http.Header{"B3": {traceID + "-" + spanID + "-1"}},
+		},
+		{
+			scenario: "b3 multiple headers",
+			header: http.Header{
+				"X-B3-Traceid": {traceID},
+				"X-B3-Spanid":  {spanID},
+				"X-B3-Sampled": {"1"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.scenario, func() {
+			ctx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.HeaderCarrier(tt.header))
+
+			sc := trace.SpanContextFromContext(ctx)
+			s.Equal(traceID, sc.TraceID().String())
+			s.Equal(spanID, sc.SpanID().String())
+			s.True(sc.IsSampled())
+
+			out := http.Header{}
+			otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(out))
+			s.Equal("00-"+traceID+"-"+spanID+"-01", out.Get("Traceparent"))
+			s.Equal(traceID, out.Get("X-B3-Traceid"))
+		})
+	}
+}
+
+func (s *TracingTestSuite) TestExporters() {
+	shutdown, err := tracing.Setup(context.Background(), tracing.ExporterStdout, "nba-stats-api")
+	s.Require().NoError(err)
+	s.NoError(shutdown(context.Background()))
+
+	_, err = tracing.Setup(context.Background(), "jaeger", "nba-stats-api")
+	s.Error(err)
+}